@@ -8,32 +8,32 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	goredis "github.com/redis/go-redis/v9"
 
 	"weather-api/internal/infrastructure"
-	"weather-api/internal/infrastructure/db/redis/weather/ttl"
-
+	"weather-api/internal/infrastructure/monitoring"
 	"weather-api/internal/infrastructure/prometheus"
-
-	geocodingapi "weather-api/internal/infrastructure/http/validator/providers/geocoding"
-	weatherapisearch "weather-api/internal/infrastructure/http/validator/providers/weather-api-search"
-	weatherapi "weather-api/internal/infrastructure/http/weather/providers/weather-api"
+	"weather-api/internal/infrastructure/providers"
 	"weather-api/pkg/logger"
 
+	"weather-api/internal/application/services/cacheadmin"
+	"weather-api/internal/application/services/manage"
+	"weather-api/internal/application/services/provider"
 	"weather-api/internal/application/services/subscription"
 	appWeather "weather-api/internal/application/services/weather"
 	"weather-api/internal/infrastructure/http/weather"
-	openmeteo "weather-api/internal/infrastructure/http/weather/providers/open-meteo"
 
 	appEmail "weather-api/internal/application/email"
 	"weather-api/internal/application/scheduled"
 	"weather-api/internal/config"
+	"weather-api/internal/domain"
+	"weather-api/internal/infrastructure/db/cache"
 	postgresconnector "weather-api/internal/infrastructure/db/postgres"
 	"weather-api/internal/infrastructure/db/redis"
-	cacheValidator "weather-api/internal/infrastructure/db/redis/validator"
-	cacheClient "weather-api/internal/infrastructure/db/redis/weather"
+	"weather-api/internal/infrastructure/db/redis/admin"
+	cacheWeather "weather-api/internal/infrastructure/db/redis/weather"
 	"weather-api/internal/infrastructure/email"
 	"weather-api/internal/infrastructure/http/validator"
 	"weather-api/internal/interface/rest"
@@ -44,12 +44,41 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
+const healthWindow = 50
+
 func main() {
 	if err := run(); err != nil {
 		log.Fatalf("Application failed to start: %v", err)
 	}
 }
 
+// connectRedis builds the Redis client without requiring Redis to be up: the
+// service starts in degraded mode and switches over once it recovers.
+func connectRedis(ctx context.Context, cfg config.RedisConfig) (goredis.UniversalClient, error) {
+	client, err := redis.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.Printf("warning: redis unavailable, caching in memory until it recovers: %v", err)
+	}
+	return client, nil
+}
+
+// withLocalTier puts the in-process LRU in front of store when it is enabled,
+// returning it as well so the invalidator can evict from it.
+func withLocalTier(
+	cfg config.LocalCacheConfig, store cache.Cache, tiers *prometheus.CacheTierMetrics,
+) (cache.Cache, *cache.LocalCache) {
+	if !cfg.Enabled {
+		return store, nil
+	}
+	local := cache.NewLocalCache(
+		cfg.Size, cfg.TTL, infrastructure.SystemClock{}, tiers.Tier(cache.TierLocal),
+	)
+	return cache.NewTieredCache(local, store), local
+}
+
 func run() error {
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -65,10 +94,10 @@ func run() error {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	redisClient, err := redis.NewClient(ctx, cfg.Redis)
+	redisClient, err := connectRedis(ctx, cfg.Redis)
 	if err != nil {
 		cancel()
-		return fmt.Errorf("failed to connect to redis: %w", err)
+		return fmt.Errorf("failed to configure redis: %w", err)
 	}
 
 	// Initialize logger
@@ -84,86 +113,135 @@ func run() error {
 
 	validatorMetrics := prometheus.NewCacheMetrics("weather-api", "validator")
 	weatherMetrics := prometheus.NewCacheMetrics("weather-api", "weather")
+	providerMetrics := prometheus.NewProviderMetrics("weather-api")
+	healthTracker := monitoring.NewHealthTracker(healthWindow, infrastructure.SystemClock{})
+	tierMetrics := prometheus.NewCacheTierMetrics("weather-api")
 
-	geoCodingApiClient := geocodingapi.NewClient(cfg.GeoCodingURL, fileLogger)
-	cachedGeoCodingClient := cacheValidator.NewProxyClient(
-		geoCodingApiClient,
-		redisClient,
-		24*time.Hour,
-		"geo-coding",
-		validatorMetrics,
-	)
-
-	weatherApiSearchClient := weatherapisearch.NewClient(
-		cfg.Weather.ApiURL, cfg.Weather.ApiKey, fileLogger)
-	cachedWeatherApiSearchClient := cacheValidator.NewProxyClient(
-		weatherApiSearchClient,
-		redisClient,
-		24*time.Hour,
-		"weather-search",
-		validatorMetrics,
+	codec, err := cache.NewCodec(cfg.Cache.Codec, cfg.Cache.CompressAbove)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to configure cache codec: %w", err)
+	}
+	redisTier := tierMetrics.Tier(cache.TierRedis)
+	redisStore := cache.NewFailoverCache(
+		cache.NewRedisCache(redisClient, redisTier, prometheus.NewRedisMetrics("weather-api")),
+		cache.NewLocalCache(
+			cfg.Cache.Fallback.Size,
+			cfg.Cache.Fallback.TTL,
+			infrastructure.SystemClock{},
+			tierMetrics.Tier(cache.TierMemory),
+		),
+		func(ctx context.Context) error { return redisClient.Ping(ctx).Err() },
+		cfg.Cache.HealthCheckInterval,
+		redisTier,
 	)
+	go redisStore.Run(ctx)
 
-	geoCodingApiHandler := validator.NewHandler(cachedGeoCodingClient)
-	geoCodingApiHandler.SetNext(cachedWeatherApiSearchClient)
-	cityValidator := validator.NewCityValidator(geoCodingApiHandler)
+	store, localCache := withLocalTier(cfg.Cache.Local, redisStore, tierMetrics)
 
-	// Initialize repositories
-	weatherApiClient := weatherapi.NewClient(
-		cfg.Weather.ApiURL,
-		cfg.Weather.ApiKey,
-		fileLogger,
-		infrastructure.SystemClock{},
-	)
-	cachedWeatherApiClient := cacheClient.NewProxyClient(
-		weatherApiClient,
+	providerBuilder := providers.NewBuilder(
+		cfg.Providers,
 		redisClient,
-		ttl.NewTTLProvider(15*time.Minute, infrastructure.SystemClock{}),
-		"weather-api",
-		weatherMetrics,
-	)
-
-	openMeteoApiClient := openmeteo.NewClient(
-		cfg.OpenMeteoURL,
-		cfg.GeoCodingURL,
+		providers.Caching{
+			Store:     store,
+			Codec:     codec,
+			Coalescer: cache.NewCoalescer(cache.NewRedisLocker(redisClient, redisStore), cfg.Cache.LockTTL),
+			Staleness: cacheWeather.Staleness{
+				WhileRevalidate: cfg.Cache.StaleWhileRevalidate,
+				IfError:         cfg.Cache.StaleIfError,
+			},
+			NegativeTTL: cfg.Cache.NegativeTTL,
+			Health:      redisStore,
+		},
+		postgresconnector.NewLocationRepository(db),
 		fileLogger,
 		infrastructure.SystemClock{},
+		providers.Metrics{
+			Retry:     prometheus.NewRetryMetrics("weather-api"),
+			Quota:     prometheus.NewQuotaMetrics("weather-api"),
+			Weather:   weatherMetrics,
+			Validator: validatorMetrics,
+			Location:  prometheus.NewCacheMetrics("weather-api", "location"),
+			Requests:  []providers.RequestRecorder{providerMetrics, healthTracker},
+			Fallback:  providerMetrics,
+		},
 	)
-	cachedOpenMeteoApi := cacheClient.NewProxyClient(
-		openMeteoApiClient,
-		redisClient,
-		ttl.NewTTLProvider(1*time.Hour, infrastructure.SystemClock{}),
-		"open-meteo",
-		weatherMetrics,
-	)
+	for _, name := range providerBuilder.EnabledProviders() {
+		healthTracker.Register(name)
+	}
+	if localCache != nil {
+		invalidator := cache.NewInvalidator(redisClient, cfg.Redis.DB, localCache)
+		go invalidator.Run(ctx, providerBuilder.CachePrefixes())
+	}
+
+	validationChain, err := providerBuilder.BuildValidationChain()
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to build validation providers: %w", err)
+	}
+	cityValidator := validator.NewCityValidator(validationChain)
+	locationResolver, err := providerBuilder.BuildLocationResolver()
+	if err != nil {
+		log.Printf("warning: subscriptions default to the %s timezone: %v", domain.DefaultTimezone, err)
+	}
 
-	openMeteoApiHandler := weather.NewHandler(cachedOpenMeteoApi)
-	openMeteoApiHandler.SetNext(cachedWeatherApiClient)
-	weatherRepository := weather.NewRepository(openMeteoApiHandler)
+	// Initialize repositories
+	weatherChain, err := providerBuilder.BuildWeatherChain()
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to build weather providers: %w", err)
+	}
+	weatherRepository := weather.NewRepository(weatherChain)
 	subscriptionRepo := postgresconnector.NewSubscriptionRepository(db)
 
 	// Initialize services
 	emailNotifier := appEmail.NewNotifier(cfg.Server.Host, emailSender)
 	weatherService := appWeather.NewService(weatherRepository)
+	providerService := provider.NewService(healthTracker)
 	subscriptionService := subscription.NewService(
-		subscriptionRepo, cityValidator, emailNotifier, cfg.Server.Host)
+		subscriptionRepo, cityValidator, locationResolver, emailNotifier, cfg.Server.Host)
+	manageService := manage.NewService(
+		subscriptionRepo,
+		manage.NewSessions(cfg.Manage.Secret, cfg.Manage.LinkTTL, infrastructure.SystemClock{}),
+		emailNotifier,
+	)
+	cacheAdminService := cacheadmin.NewService(
+		admin.NewStore(redisClient, codec),
+		weatherRepository,
+		postgresconnector.NewAuditRepository(db),
+		infrastructure.SystemClock{},
+		providerBuilder.CachePrefixes(),
+	)
 
 	// Initialize controllers
 	weatherController := rest.NewWeatherController(weatherService)
 	subscriptionController := rest.NewSubscriptionController(subscriptionService)
+	manageController := rest.NewManageController(manageService)
+	providerController := rest.NewProviderController(providerService)
+	cacheAdminController := rest.NewCacheAdminController(cacheAdminService)
 
 	// Initialize workers
 	jobManager := scheduled.NewJobManager(ctx)
 	jobManager.RegisterJob(scheduled.NewHourlyWeatherUpdateJob(
 		weatherRepository, subscriptionRepo, emailNotifier))
-	jobManager.RegisterJob(scheduled.NewDailyWeatherUpdateJob(
-		weatherRepository, subscriptionRepo, emailNotifier))
+	dailyJob := scheduled.NewDailyWeatherUpdateJob(
+		weatherRepository, subscriptionRepo, emailNotifier, infrastructure.SystemClock{})
+	jobManager.RegisterJob(dailyJob)
+	customJob := scheduled.NewCustomWeatherUpdateJob(
+		weatherRepository, subscriptionRepo, emailNotifier, infrastructure.SystemClock{})
+	jobManager.RegisterJob(customJob)
+	if cfg.Jobs.WarmupLead > 0 {
+		jobManager.RegisterJob(scheduled.NewDailyCacheWarmupJob(
+			dailyJob, cfg.Jobs.WarmupLead, cfg.Jobs.WarmupConcurrency, weatherRepository))
+		jobManager.RegisterJob(scheduled.NewCustomCacheWarmupJob(
+			customJob, cfg.Jobs.WarmupLead, cfg.Jobs.WarmupConcurrency, weatherRepository))
+	}
 	go jobManager.StartScheduler()
 
 	// Initialize router
 	router := gin.Default()
 
-	router.LoadHTMLGlob("templates/index.html")
+	router.LoadHTMLFiles("templates/index.html", "templates/manage.html")
 
 	router.Use(middleware.ErrorHandler())
 	router.Use(middleware.TransactionMiddleware(txManager))
@@ -171,6 +249,8 @@ func run() error {
 	router.GET("/", func(c *gin.Context) {
 		c.HTML(200, "index.html", nil)
 	})
+	router.GET("/manage", manageController.Page)
+	router.POST("/manage", manageController.Page)
 
 	// Metrics endpoint
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
@@ -178,9 +258,26 @@ func run() error {
 	api := router.Group("/api")
 	{
 		api.GET("/weather", weatherController.GetWeather)
+		api.GET("/weather/hourly", weatherController.GetHourlyForecast)
 		api.POST("/subscribe", subscriptionController.Subscribe)
 		api.GET("/confirm/:token", subscriptionController.Confirm)
 		api.GET("/unsubscribe/:token", subscriptionController.Unsubscribe)
+		api.PATCH("/subscription/:token", subscriptionController.Update)
+		api.GET("/providers/status", providerController.GetStatus)
+		api.POST("/manage", manageController.RequestLink)
+		api.GET("/manage/subscriptions", manageController.List)
+		api.PATCH("/manage/subscriptions/:id", manageController.Edit)
+		api.POST("/manage/subscriptions/:id/pause", manageController.Pause)
+		api.POST("/manage/subscriptions/:id/resume", manageController.Resume)
+		api.DELETE("/manage/subscriptions/:id", manageController.Delete)
+	}
+
+	adminAPI := router.Group("/api/admin", middleware.AdminAuth(cfg.Admin.Credentials))
+	{
+		adminAPI.GET("/cache/keys", cacheAdminController.ListKeys)
+		adminAPI.GET("/cache/entry", cacheAdminController.GetEntry)
+		adminAPI.DELETE("/cache", cacheAdminController.Purge)
+		adminAPI.POST("/cache/refresh", cacheAdminController.Refresh)
 	}
 
 	router.GET("/healthz", func(c *gin.Context) {
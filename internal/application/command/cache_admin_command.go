@@ -0,0 +1,14 @@
+package command
+
+import "weather-api/internal/application/common"
+
+type PurgeCacheCommand struct {
+	Actor  common.AdminActor
+	Prefix string
+	City   string
+}
+
+type RefreshCacheCommand struct {
+	Actor common.AdminActor
+	City  string
+}
@@ -0,0 +1,12 @@
+package command
+
+// EditSubscriptionCommand changes the delivery preferences of one of the
+// session's subscriptions. Empty fields keep their current values.
+type EditSubscriptionCommand struct {
+	Session      string
+	ID           uint
+	Frequency    string
+	DeliveryTime string
+	Timezone     string
+	Schedule     string
+}
@@ -5,9 +5,12 @@ import (
 )
 
 type SubscribeCommand struct {
-	Email     string
-	City      string
-	Frequency string
+	Email        string
+	City         string
+	Frequency    string
+	DeliveryTime string
+	Timezone     string
+	Schedule     string
 }
 
 func (c *SubscribeCommand) ToSubscriptionLookup() *domain.SubscriptionLookup {
@@ -17,3 +20,15 @@ func (c *SubscribeCommand) ToSubscriptionLookup() *domain.SubscriptionLookup {
 		Frequency: domain.Frequency(c.Frequency),
 	}
 }
+
+// UpdateSubscriptionCommand changes the subscription the token belongs to.
+// Empty fields keep their current values.
+type UpdateSubscriptionCommand struct {
+	Token        string
+	Email        string
+	City         string
+	Frequency    string
+	DeliveryTime string
+	Timezone     string
+	Schedule     string
+}
@@ -0,0 +1,15 @@
+package common
+
+import "time"
+
+type CacheEntry struct {
+	Key   string
+	Value []byte
+	TTL   time.Duration
+}
+
+// AdminActor identifies who performed an administrative action.
+type AdminActor struct {
+	Name       string
+	RemoteAddr string
+}
@@ -0,0 +1,13 @@
+package common
+
+import "time"
+
+type ProviderStatus struct {
+	Provider    string
+	Status      string
+	Requests    int
+	Failures    int
+	AvgLatency  time.Duration
+	LastOutcome string
+	LastSeen    time.Time
+}
@@ -0,0 +1,12 @@
+package common
+
+type WeatherHourlyResult struct {
+	Time       string
+	TempC      float64
+	WillItRain bool
+	ChanceRain int
+	WillItSnow bool
+	ChanceSnow int
+	Condition  string
+	Icon       string
+}
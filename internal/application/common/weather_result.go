@@ -1,7 +1,10 @@
 package common
 
+import "time"
+
 type WeatherResult struct {
 	Temperature float64
 	Humidity    float64
 	Description string
+	FetchedAt   time.Time
 }
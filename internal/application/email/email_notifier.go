@@ -2,6 +2,8 @@ package email
 
 import (
 	"fmt"
+	"net/url"
+	"time"
 
 	"weather-api/internal/domain"
 )
@@ -10,6 +12,7 @@ type Sender interface {
 	WeatherDailyEmail(email *WeatherDailyEmail) error
 	WeatherHourlyEmail(email *WeatherHourlyEmail) error
 	ConfirmationEmail(email *ConfirmationEmail) error
+	ManageLinkEmail(email *ManageLinkEmail) error
 }
 
 type Notifier struct {
@@ -54,14 +57,22 @@ func (n *Notifier) NotifyConfirmation(subscription *domain.Subscription) error {
 
 func (n *Notifier) NotifyHourlyWeather(
 	subscription *domain.Subscription,
-	weatherHourly *domain.WeatherHourly,
+	timeline *domain.WeatherTimeline,
 ) error {
 	emailData := &WeatherHourlyEmail{
 		To:             subscription.Email,
 		Frequency:      string(subscription.Frequency),
 		UnsubscribeURL: fmt.Sprintf("%s/api/unsubscribe/%s", n.host, subscription.Token),
-		WeatherHourly:  weatherHourly,
+		Timeline:       timeline,
 	}
 
 	return n.sender.WeatherHourlyEmail(emailData)
 }
+
+func (n *Notifier) NotifyManageLink(email, session string, expiresAt time.Time) error {
+	return n.sender.ManageLinkEmail(&ManageLinkEmail{
+		To:        email,
+		URL:       fmt.Sprintf("%s/manage#session=%s", n.host, url.QueryEscape(session)),
+		ExpiresAt: expiresAt,
+	})
+}
@@ -1,6 +1,8 @@
 package email
 
 import (
+	"time"
+
 	"weather-api/internal/domain"
 )
 
@@ -22,5 +24,11 @@ type WeatherHourlyEmail struct {
 	To             string
 	Frequency      string
 	UnsubscribeURL string
-	WeatherHourly  *domain.WeatherHourly
+	Timeline       *domain.WeatherTimeline
+}
+
+type ManageLinkEmail struct {
+	To        string
+	URL       string
+	ExpiresAt time.Time
 }
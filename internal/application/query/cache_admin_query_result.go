@@ -0,0 +1,17 @@
+package query
+
+import "weather-api/internal/application/common"
+
+type CacheKeysQueryResult struct {
+	Prefix    string
+	Keys      []string
+	Truncated bool
+}
+
+type CacheEntryQueryResult struct {
+	Entry common.CacheEntry
+}
+
+type CachePurgeQueryResult struct {
+	Deleted int
+}
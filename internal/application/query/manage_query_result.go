@@ -0,0 +1,8 @@
+package query
+
+import "weather-api/internal/domain"
+
+type ManagedSubscriptionsQueryResult struct {
+	Email         string
+	Subscriptions []*domain.Subscription
+}
@@ -0,0 +1,7 @@
+package query
+
+import "weather-api/internal/application/common"
+
+type ProviderStatusQueryResult struct {
+	Providers []common.ProviderStatus
+}
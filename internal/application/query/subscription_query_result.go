@@ -0,0 +1,8 @@
+package query
+
+import "weather-api/internal/domain"
+
+type SubscriptionUpdateQueryResult struct {
+	Subscription     *domain.Subscription
+	ConfirmationSent bool
+}
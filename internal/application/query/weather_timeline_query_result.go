@@ -0,0 +1,13 @@
+package query
+
+import (
+	"time"
+
+	"weather-api/internal/application/common"
+)
+
+type WeatherTimelineQueryResult struct {
+	Location  string
+	Hours     []common.WeatherHourlyResult
+	FetchedAt time.Time
+}
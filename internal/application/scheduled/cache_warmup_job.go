@@ -0,0 +1,148 @@
+package scheduled
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"weather-api/internal/domain"
+)
+
+// warmup is one forecast to fetch ahead of a send run.
+type warmup struct {
+	city  string
+	fetch func(ctx context.Context, city string) error
+}
+
+// CacheWarmupJob fetches the forecasts a send job needs a lead time before it
+// runs, so the send phase is served from the cache.
+type CacheWarmupJob struct {
+	sendJob     Job
+	lead        time.Duration
+	concurrency int
+	due         func(ctx context.Context) ([]warmup, error)
+}
+
+// NewDailyCacheWarmupJob warms only the cities of subscriptions due at the
+// send run a lead time from now, since daily subscriptions are spread over
+// the day by their delivery times.
+func NewDailyCacheWarmupJob(
+	sendJob *DailyWeatherUpdateJob,
+	lead time.Duration,
+	concurrency int,
+	weatherRepo WeatherDailyReader,
+) *CacheWarmupJob {
+	fetch := func(ctx context.Context, city string) error {
+		_, err := weatherRepo.GetDailyForecast(ctx, city)
+		return err
+	}
+	due := func(ctx context.Context) ([]warmup, error) {
+		groups, err := sendJob.Due(ctx, sendJob.clock.Now().Add(lead))
+		if err != nil {
+			return nil, err
+		}
+		return warmups(groups, fetch), nil
+	}
+	return newCacheWarmupJob(sendJob, lead, concurrency, due)
+}
+
+// NewCustomCacheWarmupJob warms what the custom send run a lead time from now
+// needs: the daily forecast for one-off schedules and the hourly timeline for
+// repeating ones.
+func NewCustomCacheWarmupJob(
+	sendJob *CustomWeatherUpdateJob,
+	lead time.Duration,
+	concurrency int,
+	weatherRepo CustomWeatherReader,
+) *CacheWarmupJob {
+	fetchDaily := func(ctx context.Context, city string) error {
+		_, err := weatherRepo.GetDailyForecast(ctx, city)
+		return err
+	}
+	fetchTimeline := func(ctx context.Context, city string) error {
+		_, err := weatherRepo.GetHourlyTimeline(ctx, city, hourlyEmailHours)
+		return err
+	}
+	due := func(ctx context.Context) ([]warmup, error) {
+		groups, err := sendJob.Due(ctx, sendJob.clock.Now().Add(lead))
+		if err != nil {
+			return nil, err
+		}
+		once, repeating := splitRepeating(groups)
+		return append(warmups(once, fetchDaily), warmups(repeating, fetchTimeline)...), nil
+	}
+	return newCacheWarmupJob(sendJob, lead, concurrency, due)
+}
+
+func newCacheWarmupJob(
+	sendJob Job,
+	lead time.Duration,
+	concurrency int,
+	due func(ctx context.Context) ([]warmup, error),
+) *CacheWarmupJob {
+	return &CacheWarmupJob{
+		sendJob:     sendJob,
+		lead:        lead,
+		concurrency: max(concurrency, 1),
+		due:         due,
+	}
+}
+
+func warmups(
+	groups []*domain.GroupedSubscription, fetch func(ctx context.Context, city string) error,
+) []warmup {
+	due := make([]warmup, 0, len(groups))
+	for _, group := range groups {
+		due = append(due, warmup{city: group.City, fetch: fetch})
+	}
+	return due
+}
+
+func (w *CacheWarmupJob) Name() string {
+	return w.sendJob.Name() + "Warmup"
+}
+
+func (w *CacheWarmupJob) Schedule() string {
+	return w.sendJob.Schedule()
+}
+
+func (w *CacheWarmupJob) Lead() time.Duration {
+	return w.lead
+}
+
+// Run warms the cache for every forecast the next send run needs. It gives up
+// once the send job is due, since from then on the send job fetches what is
+// missing.
+func (w *CacheWarmupJob) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, w.lead)
+	defer cancel()
+
+	due, err := w.due(ctx)
+	if err != nil || len(due) == 0 {
+		return err
+	}
+
+	var failed atomic.Int32
+	var group errgroup.Group
+	group.SetLimit(w.concurrency)
+	for _, task := range due {
+		group.Go(func() error {
+			if err := task.fetch(ctx, task.city); err != nil {
+				log.Printf("Failed to warm cache for city %s: %v", task.city, err)
+				failed.Add(1)
+			}
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	if failed.Load() > 0 {
+		return fmt.Errorf("failed to warm cache for %d of %d cities", failed.Load(), len(due))
+	}
+	log.Printf("Warmed cache for %d cities ahead of %s", len(due), w.sendJob.Name())
+	return nil
+}
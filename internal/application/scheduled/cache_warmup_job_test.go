@@ -0,0 +1,139 @@
+//go:build unit
+// +build unit
+
+package scheduled
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/domain"
+	"weather-api/internal/test/mocks"
+)
+
+type warmRecorder struct {
+	mu      sync.Mutex
+	cities  []string
+	active  atomic.Int32
+	peak    atomic.Int32
+	failFor string
+}
+
+func (r *warmRecorder) warm(_ context.Context, city string) error {
+	active := r.active.Add(1)
+	defer r.active.Add(-1)
+	for {
+		peak := r.peak.Load()
+		if active <= peak || r.peak.CompareAndSwap(peak, active) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cities = append(r.cities, city)
+	if city == r.failFor {
+		return errors.New("provider unavailable")
+	}
+	return nil
+}
+
+func newWarmupJob(cities []string, concurrency int, recorder *warmRecorder) *CacheWarmupJob {
+	sendJob := NewDailyWeatherUpdateJob(nil, &mocks.MockSubscriptionRepository{}, nil, fixedClock{})
+	due := func(context.Context) ([]warmup, error) {
+		var due []warmup
+		for _, city := range cities {
+			due = append(due, warmup{city: city, fetch: recorder.warm})
+		}
+		return due, nil
+	}
+	return newCacheWarmupJob(sendJob, time.Minute, concurrency, due)
+}
+
+func TestCacheWarmupJob_WarmsEveryCityWithBoundedConcurrency(t *testing.T) {
+	cities := []string{"Kyiv", "Lviv", "Odesa", "Kharkiv", "Dnipro", "Poltava"}
+	recorder := &warmRecorder{}
+	job := newWarmupJob(cities, 2, recorder)
+
+	err := job.Run(context.Background())
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, cities, recorder.cities)
+	assert.LessOrEqual(t, recorder.peak.Load(), int32(2))
+}
+
+func TestCacheWarmupJob_ReportsFailedCities(t *testing.T) {
+	recorder := &warmRecorder{failFor: "Lviv"}
+	job := newWarmupJob([]string{"Kyiv", "Lviv"}, 2, recorder)
+
+	err := job.Run(context.Background())
+
+	require.Error(t, err)
+	assert.ElementsMatch(t, []string{"Kyiv", "Lviv"}, recorder.cities)
+}
+
+func TestCacheWarmupJob_RunsAheadOfSendJob(t *testing.T) {
+	job := newWarmupJob(nil, 1, &warmRecorder{})
+
+	assert.Equal(t, "DailyWeatherUpdateJobWarmup", job.Name())
+	assert.Equal(t, "0 * * * * *", job.Schedule())
+	assert.Equal(t, time.Minute, job.Lead())
+}
+
+func TestDailyCacheWarmupJob_WarmsCitiesDueAfterLead(t *testing.T) {
+	now := time.Date(2025, 6, 26, 7, 50, 0, 0, time.UTC)
+	sendAt := now.Add(10 * time.Minute)
+	repo := &mocks.MockSubscriptionRepository{}
+	repo.On("FindDueSubscriptions", mock.Anything, sendAt.Add(-time.Minute), sendAt).
+		Return([]*domain.GroupedSubscription{{City: "Kyiv"}, {City: "Tokyo"}}, nil)
+	weatherRepo := &mocks.MockWeatherRepository{}
+	weatherRepo.On("GetDailyForecast", mock.Anything, mock.Anything).
+		Return(&domain.WeatherDaily{}, nil)
+	sendJob := NewDailyWeatherUpdateJob(weatherRepo, repo, nil, fixedClock{now: now})
+	job := NewDailyCacheWarmupJob(sendJob, 10*time.Minute, 2, weatherRepo)
+
+	err := job.Run(context.Background())
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	weatherRepo.AssertCalled(t, "GetDailyForecast", mock.Anything, "Kyiv")
+	weatherRepo.AssertCalled(t, "GetDailyForecast", mock.Anything, "Tokyo")
+}
+
+func TestCustomCacheWarmupJob_WarmsWhatDueSchedulesNeed(t *testing.T) {
+	// Thursday 06:50 in Kyiv, ten minutes before the sends.
+	now := time.Date(2025, 6, 26, 3, 50, 0, 0, time.UTC)
+	weekdays := &domain.Subscription{ID: 1, City: "Kyiv", Frequency: domain.FrequencyCustom,
+		Schedule: "weekdays at 07:00", Timezone: "Europe/Kyiv"}
+	interval := &domain.Subscription{ID: 2, City: "Lviv", Frequency: domain.FrequencyCustom,
+		Schedule: "every 1 hour between 06:00 and 22:00", Timezone: "Europe/Kyiv"}
+	repo := &mocks.MockSubscriptionRepository{}
+	repo.On("FindScheduledSubscriptions", mock.Anything, now.Add(10*time.Minute)).
+		Return([]*domain.GroupedSubscription{
+			{City: "Kyiv", Subscriptions: []*domain.Subscription{weekdays}},
+			{City: "Lviv", Subscriptions: []*domain.Subscription{interval}},
+		}, nil)
+	weatherRepo := &mocks.MockWeatherRepository{}
+	weatherRepo.On("GetDailyForecast", mock.Anything, "Kyiv").
+		Return(&domain.WeatherDaily{}, nil)
+	weatherRepo.On("GetHourlyTimeline", mock.Anything, "Lviv", hourlyEmailHours).
+		Return(&domain.WeatherTimeline{}, nil)
+	sendJob := NewCustomWeatherUpdateJob(weatherRepo, repo, nil, fixedClock{now: now})
+	job := NewCustomCacheWarmupJob(sendJob, 10*time.Minute, 2, weatherRepo)
+
+	err := job.Run(context.Background())
+
+	require.NoError(t, err)
+	weatherRepo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "SetNextRuns", mock.Anything, mock.Anything)
+	assert.Equal(t, "CustomWeatherUpdateJobWarmup", job.Name())
+}
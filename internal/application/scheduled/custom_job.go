@@ -0,0 +1,169 @@
+package scheduled
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"weather-api/internal/domain"
+)
+
+type CustomWeatherReader interface {
+	WeatherDailyReader
+	WeatherHourlyReader
+}
+
+type CustomWeatherNotifier interface {
+	WeatherDailyNotifier
+	WeatherHourlyNotifier
+}
+
+type ScheduledSubscriptionRepository interface {
+	FindScheduledSubscriptions(
+		ctx context.Context, until time.Time,
+	) ([]*domain.GroupedSubscription, error)
+	SetNextRuns(ctx context.Context, runs map[uint]time.Time) error
+}
+
+// CustomWeatherUpdateJob sends custom subscriptions their updates whenever
+// their schedule fires. Repeating schedules get the hourly timeline, the
+// others the daily forecast. Each run loads only the subscriptions whose
+// stored next run has come, and stores the next one after it. An occurrence
+// missed while the service was down or a run overran is sent once, late, on
+// the next run; further occurrences missed in the meantime are skipped.
+type CustomWeatherUpdateJob struct {
+	weather       CustomWeatherReader
+	subscriptions ScheduledSubscriptionRepository
+	notifier      CustomWeatherNotifier
+	clock         Clock
+}
+
+func NewCustomWeatherUpdateJob(
+	weatherRepo CustomWeatherReader,
+	subscriptionRepo ScheduledSubscriptionRepository,
+	notifier CustomWeatherNotifier,
+	clock Clock,
+) *CustomWeatherUpdateJob {
+	return &CustomWeatherUpdateJob{
+		weather:       weatherRepo,
+		subscriptions: subscriptionRepo,
+		notifier:      notifier,
+		clock:         clock,
+	}
+}
+
+func (j *CustomWeatherUpdateJob) Name() string {
+	return "CustomWeatherUpdateJob"
+}
+
+func (j *CustomWeatherUpdateJob) Schedule() string {
+	return "0 * * * * *"
+}
+
+func (j *CustomWeatherUpdateJob) Run(ctx context.Context) error {
+	to := j.clock.Now().Truncate(deliveryInterval)
+	scheduled, err := j.subscriptions.FindScheduledSubscriptions(ctx, to)
+	if err != nil {
+		return err
+	}
+	once, repeating := splitRepeating(due(scheduled, to.Add(-deliveryInterval), to))
+
+	daily := NewFindingWeatherJobExecutor(
+		found(once),
+		domain.FrequencyCustom,
+		j.weather.GetDailyForecast,
+		j.notifier.NotifyDailyWeather,
+	)
+	hourly := NewFindingWeatherJobExecutor(
+		found(repeating),
+		domain.FrequencyCustom,
+		func(ctx context.Context, city string) (*domain.WeatherTimeline, error) {
+			return j.weather.GetHourlyTimeline(ctx, city, hourlyEmailHours)
+		},
+		j.notifier.NotifyHourlyWeather,
+	)
+	return errors.Join(
+		daily.Execute(ctx),
+		hourly.Execute(ctx),
+		j.subscriptions.SetNextRuns(ctx, nextRuns(scheduled, to)),
+	)
+}
+
+// Due returns the custom subscriptions the run at the given time sends to.
+func (j *CustomWeatherUpdateJob) Due(
+	ctx context.Context, at time.Time,
+) ([]*domain.GroupedSubscription, error) {
+	to := at.Truncate(deliveryInterval)
+	scheduled, err := j.subscriptions.FindScheduledSubscriptions(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	return due(scheduled, to.Add(-deliveryInterval), to), nil
+}
+
+// due keeps the subscriptions whose schedule fires in (from, to] and those
+// whose stored next run has already passed without being sent.
+func due(
+	groups []*domain.GroupedSubscription, from, to time.Time,
+) []*domain.GroupedSubscription {
+	return filterSubscriptions(groups, func(subscription *domain.Subscription) bool {
+		overdue := subscription.NextRunAt != nil && !subscription.NextRunAt.After(to)
+		return overdue || subscription.DueBetween(from, to)
+	})
+}
+
+// nextRuns computes when each loaded subscription fires after the run ending
+// at the given time, so the next runs skip it until then.
+func nextRuns(groups []*domain.GroupedSubscription, after time.Time) map[uint]time.Time {
+	runs := make(map[uint]time.Time)
+	for _, group := range groups {
+		for _, subscription := range group.Subscriptions {
+			if next, ok := subscription.NextRun(after); ok {
+				runs[subscription.ID] = next
+			}
+		}
+	}
+	return runs
+}
+
+func splitRepeating(
+	groups []*domain.GroupedSubscription,
+) (once, repeating []*domain.GroupedSubscription) {
+	isRepeating := func(subscription *domain.Subscription) bool {
+		schedule, err := domain.ParseSchedule(subscription.Schedule)
+		return err == nil && schedule.Repeating()
+	}
+	once = filterSubscriptions(groups, func(subscription *domain.Subscription) bool {
+		return !isRepeating(subscription)
+	})
+	return once, filterSubscriptions(groups, isRepeating)
+}
+
+// filterSubscriptions keeps the matching subscriptions and drops groups left
+// empty.
+func filterSubscriptions(
+	groups []*domain.GroupedSubscription, keep func(*domain.Subscription) bool,
+) []*domain.GroupedSubscription {
+	var filtered []*domain.GroupedSubscription
+	for _, group := range groups {
+		var subscriptions []*domain.Subscription
+		for _, subscription := range group.Subscriptions {
+			if keep(subscription) {
+				subscriptions = append(subscriptions, subscription)
+			}
+		}
+		if len(subscriptions) > 0 {
+			filtered = append(filtered, &domain.GroupedSubscription{
+				City:          group.City,
+				Subscriptions: subscriptions,
+			})
+		}
+	}
+	return filtered
+}
+
+func found(groups []*domain.GroupedSubscription) SubscriptionFinder {
+	return func(context.Context) ([]*domain.GroupedSubscription, error) {
+		return groups, nil
+	}
+}
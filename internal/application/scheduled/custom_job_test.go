@@ -0,0 +1,101 @@
+//go:build unit
+// +build unit
+
+package scheduled
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/domain"
+	"weather-api/internal/test/mocks"
+)
+
+func TestCustomWeatherUpdateJob_NotifiesDueSchedules(t *testing.T) {
+	// Thursday 07:00:02 in Kyiv.
+	now := time.Date(2025, 6, 26, 4, 0, 2, 0, time.UTC)
+	weekdays := &domain.Subscription{ID: 1, City: "Kyiv", Frequency: domain.FrequencyCustom,
+		Schedule: "weekdays at 07:00", Timezone: "Europe/Kyiv"}
+	interval := &domain.Subscription{ID: 2, City: "Kyiv", Frequency: domain.FrequencyCustom,
+		Schedule: "every 1 hour between 06:00 and 22:00", Timezone: "Europe/Kyiv"}
+	weekly := &domain.Subscription{ID: 3, City: "Lviv", Frequency: domain.FrequencyCustom,
+		Schedule: "weekly on sunday", DeliveryTime: "07:00", Timezone: "Europe/Kyiv"}
+	forecast := &domain.WeatherDaily{Location: "Kyiv"}
+	timeline := &domain.WeatherTimeline{}
+
+	repo := &mocks.MockSubscriptionRepository{}
+	repo.On("FindScheduledSubscriptions", mock.Anything, now.Truncate(time.Minute)).
+		Return([]*domain.GroupedSubscription{
+			{City: "Kyiv", Subscriptions: []*domain.Subscription{weekdays, interval}},
+			{City: "Lviv", Subscriptions: []*domain.Subscription{weekly}},
+		}, nil)
+	// Friday 07:00, 08:00 today and Sunday 07:00 in Kyiv.
+	nextRuns := map[uint]time.Time{
+		1: time.Date(2025, 6, 27, 4, 0, 0, 0, time.UTC),
+		2: time.Date(2025, 6, 26, 5, 0, 0, 0, time.UTC),
+		3: time.Date(2025, 6, 29, 4, 0, 0, 0, time.UTC),
+	}
+	repo.On("SetNextRuns", mock.Anything, mock.MatchedBy(func(runs map[uint]time.Time) bool {
+		if len(runs) != len(nextRuns) {
+			return false
+		}
+		for id, next := range nextRuns {
+			if !runs[id].Equal(next) {
+				return false
+			}
+		}
+		return true
+	})).Return(nil)
+	weatherRepo := &mocks.MockWeatherRepository{}
+	weatherRepo.On("GetDailyForecast", mock.Anything, "Kyiv").Return(forecast, nil)
+	weatherRepo.On("GetHourlyTimeline", mock.Anything, "Kyiv", hourlyEmailHours).
+		Return(timeline, nil)
+	notifier := &mocks.MockNotifier{}
+	notifier.On("NotifyDailyWeather", weekdays, forecast).Return(nil)
+	notifier.On("NotifyHourlyWeather", interval, timeline).Return(nil)
+
+	job := NewCustomWeatherUpdateJob(weatherRepo, repo, notifier, fixedClock{now: now})
+	err := job.Run(context.Background())
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	weatherRepo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+	weatherRepo.AssertNotCalled(t, "GetDailyForecast", mock.Anything, "Lviv")
+}
+
+func TestCustomWeatherUpdateJob_SendsMissedOccurrencesOnce(t *testing.T) {
+	// Thursday 10:30:02 in Kyiv; the run at 07:00 was missed.
+	now := time.Date(2025, 6, 26, 7, 30, 2, 0, time.UTC)
+	missed := time.Date(2025, 6, 26, 4, 0, 0, 0, time.UTC)
+	subscription := &domain.Subscription{ID: 1, City: "Kyiv", Frequency: domain.FrequencyCustom,
+		Schedule: "weekdays at 07:00", Timezone: "Europe/Kyiv", NextRunAt: &missed}
+	forecast := &domain.WeatherDaily{Location: "Kyiv"}
+
+	repo := &mocks.MockSubscriptionRepository{}
+	repo.On("FindScheduledSubscriptions", mock.Anything, now.Truncate(time.Minute)).
+		Return([]*domain.GroupedSubscription{
+			{City: "Kyiv", Subscriptions: []*domain.Subscription{subscription}},
+		}, nil)
+	// Friday 07:00 in Kyiv.
+	next := time.Date(2025, 6, 27, 4, 0, 0, 0, time.UTC)
+	repo.On("SetNextRuns", mock.Anything, mock.MatchedBy(func(runs map[uint]time.Time) bool {
+		return len(runs) == 1 && runs[1].Equal(next)
+	})).Return(nil)
+	weatherRepo := &mocks.MockWeatherRepository{}
+	weatherRepo.On("GetDailyForecast", mock.Anything, "Kyiv").Return(forecast, nil).Once()
+	notifier := &mocks.MockNotifier{}
+	notifier.On("NotifyDailyWeather", subscription, forecast).Return(nil).Once()
+
+	job := NewCustomWeatherUpdateJob(weatherRepo, repo, notifier, fixedClock{now: now})
+	err := job.Run(context.Background())
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	weatherRepo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
@@ -2,10 +2,15 @@ package scheduled
 
 import (
 	"context"
+	"time"
 
 	"weather-api/internal/domain"
 )
 
+// deliveryInterval is how often the daily and custom jobs look for
+// subscriptions whose local delivery time has come. It must match Schedule.
+const deliveryInterval = time.Minute
+
 type WeatherDailyNotifier interface {
 	NotifyDailyWeather(
 		subscription *domain.Subscription,
@@ -17,15 +22,32 @@ type WeatherDailyReader interface {
 	GetDailyForecast(ctx context.Context, city string) (*domain.WeatherDaily, error)
 }
 
+type DueSubscriptionReader interface {
+	FindDueSubscriptions(
+		ctx context.Context, from, to time.Time,
+	) ([]*domain.GroupedSubscription, error)
+}
+
+type Clock interface {
+	Now() time.Time
+}
+
+// DailyWeatherUpdateJob sends each daily subscription its forecast at the
+// subscriber's delivery time, in the subscriber's own timezone.
 type DailyWeatherUpdateJob struct {
-	executor *WeatherJobExecutor[*domain.WeatherDaily]
+	executor      *WeatherJobExecutor[*domain.WeatherDaily]
+	subscriptions DueSubscriptionReader
+	clock         Clock
 }
 
 func NewDailyWeatherUpdateJob(
 	weatherRepo WeatherDailyReader,
-	subscriptionRepo GroupedSubscriptionReader,
+	subscriptionRepo DueSubscriptionReader,
 	notifier WeatherDailyNotifier,
+	clock Clock,
 ) *DailyWeatherUpdateJob {
+	job := &DailyWeatherUpdateJob{subscriptions: subscriptionRepo, clock: clock}
+
 	getWeatherFunc := func(ctx context.Context, city string) (*domain.WeatherDaily, error) {
 		return weatherRepo.GetDailyForecast(ctx, city)
 	}
@@ -37,16 +59,17 @@ func NewDailyWeatherUpdateJob(
 		return notifier.NotifyDailyWeather(subscription, weatherDaily)
 	}
 
-	exec := NewWeatherJobExecutor(
-		subscriptionRepo,
-		"daily",
+	findFunc := func(ctx context.Context) ([]*domain.GroupedSubscription, error) {
+		return job.Due(ctx, clock.Now())
+	}
+
+	job.executor = NewFindingWeatherJobExecutor(
+		findFunc,
+		domain.FrequencyDaily,
 		getWeatherFunc,
 		notifyFunc,
 	)
-
-	return &DailyWeatherUpdateJob{
-		executor: exec,
-	}
+	return job
 }
 
 func (d *DailyWeatherUpdateJob) Name() string {
@@ -54,9 +77,18 @@ func (d *DailyWeatherUpdateJob) Name() string {
 }
 
 func (d *DailyWeatherUpdateJob) Schedule() string {
-	return "0 0 8 * * *"
+	return "0 * * * * *"
 }
 
 func (d *DailyWeatherUpdateJob) Run(ctx context.Context) error {
 	return d.executor.Execute(ctx)
 }
+
+// Due returns the subscriptions the run at the given time notifies: those
+// whose delivery time falls in the interval ending at that run.
+func (d *DailyWeatherUpdateJob) Due(
+	ctx context.Context, at time.Time,
+) ([]*domain.GroupedSubscription, error) {
+	to := at.Truncate(deliveryInterval)
+	return d.subscriptions.FindDueSubscriptions(ctx, to.Add(-deliveryInterval), to)
+}
@@ -0,0 +1,60 @@
+//go:build unit
+// +build unit
+
+package scheduled
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/domain"
+	"weather-api/internal/test/mocks"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+func TestDailyWeatherUpdateJob_NotifiesSubscriptionsDueInLastInterval(t *testing.T) {
+	now := time.Date(2025, 6, 26, 5, 0, 2, 0, time.UTC)
+	windowEnd := time.Date(2025, 6, 26, 5, 0, 0, 0, time.UTC)
+	due := &domain.Subscription{ID: 1, City: "Kyiv", DeliveryTime: "08:00", Timezone: "Europe/Kyiv"}
+	forecast := &domain.WeatherDaily{Location: "Kyiv"}
+
+	repo := &mocks.MockSubscriptionRepository{}
+	repo.On("FindDueSubscriptions", mock.Anything, windowEnd.Add(-time.Minute), windowEnd).
+		Return([]*domain.GroupedSubscription{
+			{City: "Kyiv", Subscriptions: []*domain.Subscription{due}},
+		}, nil)
+	weatherRepo := &mocks.MockWeatherRepository{}
+	weatherRepo.On("GetDailyForecast", mock.Anything, "Kyiv").Return(forecast, nil)
+	notifier := &mocks.MockNotifier{}
+	notifier.On("NotifyDailyWeather", due, forecast).Return(nil)
+
+	job := NewDailyWeatherUpdateJob(weatherRepo, repo, notifier, fixedClock{now: now})
+	err := job.Run(context.Background())
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
+
+func TestDailyWeatherUpdateJob_RunsEveryDeliveryInterval(t *testing.T) {
+	job := NewDailyWeatherUpdateJob(nil, nil, nil, fixedClock{})
+	schedule, err := NewJobManager(context.Background()).parser.Parse(job.Schedule())
+	require.NoError(t, err)
+
+	at := time.Date(2025, 6, 26, 7, 59, 30, 0, time.UTC)
+	next := schedule.Next(at)
+
+	assert.Equal(t, deliveryInterval, schedule.Next(next).Sub(next))
+}
@@ -6,19 +6,21 @@ import (
 	"weather-api/internal/domain"
 )
 
+const hourlyEmailHours = 6
+
 type WeatherHourlyNotifier interface {
 	NotifyHourlyWeather(
 		subscription *domain.Subscription,
-		weatherHourly *domain.WeatherHourly,
+		timeline *domain.WeatherTimeline,
 	) error
 }
 
 type WeatherHourlyReader interface {
-	GetHourlyForecast(ctx context.Context, city string) (*domain.WeatherHourly, error)
+	GetHourlyTimeline(ctx context.Context, city string, hours int) (*domain.WeatherTimeline, error)
 }
 
 type HourlyWeatherUpdateJob struct {
-	executor *WeatherJobExecutor[*domain.WeatherHourly]
+	executor *WeatherJobExecutor[*domain.WeatherTimeline]
 }
 
 func NewHourlyWeatherUpdateJob(
@@ -26,15 +28,15 @@ func NewHourlyWeatherUpdateJob(
 	subscriptionRepo GroupedSubscriptionReader,
 	notifier WeatherHourlyNotifier,
 ) *HourlyWeatherUpdateJob {
-	getWeatherFunc := func(ctx context.Context, city string) (*domain.WeatherHourly, error) {
-		return weatherRepo.GetHourlyForecast(ctx, city)
+	getWeatherFunc := func(ctx context.Context, city string) (*domain.WeatherTimeline, error) {
+		return weatherRepo.GetHourlyTimeline(ctx, city, hourlyEmailHours)
 	}
 
 	notifyFunc := func(
 		subscription *domain.Subscription,
-		weatherHourly *domain.WeatherHourly,
+		timeline *domain.WeatherTimeline,
 	) error {
-		return notifier.NotifyHourlyWeather(subscription, weatherHourly)
+		return notifier.NotifyHourlyWeather(subscription, timeline)
 	}
 
 	exec := NewWeatherJobExecutor(
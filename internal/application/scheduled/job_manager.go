@@ -3,19 +3,32 @@ package scheduled
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/robfig/cron/v3"
 )
 
+// LeadingJob is a job that runs a lead time before each time its schedule
+// fires rather than at it.
+type LeadingJob interface {
+	Job
+	Lead() time.Duration
+}
+
 type JobManager struct {
 	cron    *cron.Cron
+	parser  cron.Parser
 	jobs    []Job
 	context context.Context
 }
 
 func NewJobManager(ctx context.Context) *JobManager {
+	parser := cron.NewParser(
+		cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+	)
 	return &JobManager{
-		cron:    cron.New(cron.WithSeconds()),
+		cron:    cron.New(cron.WithParser(parser)),
+		parser:  parser,
 		jobs:    []Job{},
 		context: ctx,
 	}
@@ -27,16 +40,36 @@ func (jm *JobManager) RegisterJob(job Job) {
 
 func (jm *JobManager) StartScheduler() {
 	for _, job := range jm.jobs {
-		schedule := job.Schedule()
-		if _, err := jm.cron.AddFunc(schedule, func() {
+		schedule, err := jm.parser.Parse(job.Schedule())
+		if err != nil {
+			log.Printf("Failed to schedule job %s: %v", job.Name(), err)
+			continue
+		}
+		if leading, ok := job.(LeadingJob); ok {
+			schedule = leadSchedule{schedule: schedule, lead: leading.Lead()}
+		}
+
+		// Jobs log their own work; runs that succeed are not logged here, as
+		// most jobs run every minute or more often.
+		jm.cron.Schedule(schedule, cron.FuncJob(func() {
 			if err := job.Run(jm.context); err != nil {
 				log.Printf("Error in job %s: %v", job.Name(), err)
-			} else {
-				log.Printf("Job %s executed successfully", job.Name())
 			}
-		}); err != nil {
-			log.Printf("Failed to schedule job %s: %v", job.Name(), err)
-		}
+		}))
 	}
 	jm.cron.Start()
 }
+
+// leadSchedule fires lead before each activation of the wrapped schedule.
+type leadSchedule struct {
+	schedule cron.Schedule
+	lead     time.Duration
+}
+
+func (s leadSchedule) Next(t time.Time) time.Time {
+	next := s.schedule.Next(t.Add(s.lead))
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(-s.lead)
+}
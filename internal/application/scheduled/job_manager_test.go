@@ -0,0 +1,47 @@
+//go:build unit
+// +build unit
+
+package scheduled
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeadSchedule_FiresBeforeWrappedSchedule(t *testing.T) {
+	daily, err := NewJobManager(context.Background()).parser.Parse("0 0 8 * * *")
+	require.NoError(t, err)
+	schedule := leadSchedule{schedule: daily, lead: 10 * time.Minute}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "before the lead window",
+			now:  time.Date(2025, 6, 26, 6, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 6, 26, 7, 50, 0, 0, time.UTC),
+		},
+		{
+			name: "inside the lead window",
+			now:  time.Date(2025, 6, 26, 7, 55, 0, 0, time.UTC),
+			want: time.Date(2025, 6, 27, 7, 50, 0, 0, time.UTC),
+		},
+		{
+			name: "after the send time",
+			now:  time.Date(2025, 6, 26, 9, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 6, 27, 7, 50, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, schedule.Next(tt.now))
+		})
+	}
+}
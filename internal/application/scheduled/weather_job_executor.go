@@ -16,7 +16,7 @@ type GroupedSubscriptionReader interface {
 }
 
 type WeatherData interface {
-	*domain.WeatherHourly | *domain.WeatherDaily
+	*domain.WeatherHourly | *domain.WeatherDaily | *domain.WeatherTimeline
 }
 
 type Task[T WeatherData] struct {
@@ -24,40 +24,63 @@ type Task[T WeatherData] struct {
 	WeatherData  T
 }
 
+// SubscriptionFinder selects the subscriptions one run of a job notifies.
+type SubscriptionFinder func(ctx context.Context) ([]*domain.GroupedSubscription, error)
+
 type WeatherJobExecutor[T WeatherData] struct {
-	subscriptionRepo GroupedSubscriptionReader
-	workerCount      int
-	frequency        domain.Frequency
-	getWeatherFunc   func(ctx context.Context, city string) (T, error)
-	notifyFunc       func(*domain.Subscription, T) error
+	findSubscriptions SubscriptionFinder
+	workerCount       int
+	frequency         domain.Frequency
+	getWeatherFunc    func(ctx context.Context, city string) (T, error)
+	notifyFunc        func(*domain.Subscription, T) error
 }
 
+// NewWeatherJobExecutor notifies every confirmed subscription of the given
+// frequency on each run.
 func NewWeatherJobExecutor[T WeatherData](
 	subscriptionRepo GroupedSubscriptionReader,
 	frequency domain.Frequency,
 	getWeatherFunc func(ctx context.Context, city string) (T, error),
 	notifyFunc func(*domain.Subscription, T) error,
+) *WeatherJobExecutor[T] {
+	find := func(ctx context.Context) ([]*domain.GroupedSubscription, error) {
+		return subscriptionRepo.FindGroupedSubscriptions(ctx, &frequency)
+	}
+	return NewFindingWeatherJobExecutor(find, frequency, getWeatherFunc, notifyFunc)
+}
+
+// NewFindingWeatherJobExecutor notifies the subscriptions find selects on each
+// run.
+func NewFindingWeatherJobExecutor[T WeatherData](
+	find SubscriptionFinder,
+	frequency domain.Frequency,
+	getWeatherFunc func(ctx context.Context, city string) (T, error),
+	notifyFunc func(*domain.Subscription, T) error,
 ) *WeatherJobExecutor[T] {
 	return &WeatherJobExecutor[T]{
-		subscriptionRepo: subscriptionRepo,
-		workerCount:      10,
-		frequency:        frequency,
-		getWeatherFunc:   getWeatherFunc,
-		notifyFunc:       notifyFunc,
+		findSubscriptions: find,
+		workerCount:       10,
+		frequency:         frequency,
+		getWeatherFunc:    getWeatherFunc,
+		notifyFunc:        notifyFunc,
 	}
 }
 
+// Execute notifies the subscriptions found for this run. Runs with nothing to
+// send log nothing, since the daily and custom jobs run every minute.
 func (e *WeatherJobExecutor[T]) Execute(ctx context.Context) error {
-	log.Printf("Weather job started for frequency: %s", e.frequency)
-
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
-	groupedSubscriptions, err := e.subscriptionRepo.FindGroupedSubscriptions(ctx, &e.frequency)
+	groupedSubscriptions, err := e.findSubscriptions(ctx)
 	if err != nil {
 		log.Printf("Failed to fetch subscriptions: %v", err)
 		return err
 	}
+	if len(groupedSubscriptions) == 0 {
+		return nil
+	}
+	log.Printf("Weather job started for frequency: %s", e.frequency)
 
 	taskChan, errChan, wg := e.startWorkers(groupedSubscriptions)
 
@@ -0,0 +1,233 @@
+package cacheadmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"weather-api/internal/application/command"
+	"weather-api/internal/application/common"
+	"weather-api/internal/application/query"
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+const (
+	ActionListKeys = "cache.list"
+	ActionGetEntry = "cache.get"
+	ActionPurge    = "cache.purge"
+	ActionRefresh  = "cache.refresh"
+
+	outcomeOK     = "ok"
+	maxListedKeys = 1000
+)
+
+type Store interface {
+	Keys(ctx context.Context, prefix, city string) ([]string, error)
+	Entry(ctx context.Context, key string) (*common.CacheEntry, error)
+	Delete(ctx context.Context, keys []string) (int, error)
+}
+
+type WeatherReader interface {
+	GetWeather(ctx context.Context, city string) (*domain.Weather, error)
+	GetDailyForecast(ctx context.Context, city string) (*domain.WeatherDaily, error)
+	GetHourlyForecast(ctx context.Context, city string) (*domain.WeatherHourly, error)
+}
+
+type AuditLog interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+}
+
+type Clock interface {
+	Now() time.Time
+}
+
+// Service inspects and purges cached provider data. Only keys under the known
+// cache prefixes are reachable, and every action is written to the audit log.
+type Service struct {
+	store    Store
+	weather  WeatherReader
+	audit    AuditLog
+	clock    Clock
+	prefixes []string
+}
+
+func NewService(
+	store Store, weather WeatherReader, audit AuditLog, clock Clock, prefixes []string,
+) *Service {
+	return &Service{
+		store:    store,
+		weather:  weather,
+		audit:    audit,
+		clock:    clock,
+		prefixes: prefixes,
+	}
+}
+
+func (s *Service) ListKeys(
+	ctx context.Context, actor common.AdminActor, prefix string,
+) (*query.CacheKeysQueryResult, error) {
+	result, err := s.listKeys(ctx, prefix)
+	s.record(ctx, actor, ActionListKeys, prefix, err)
+	return result, err
+}
+
+func (s *Service) GetEntry(
+	ctx context.Context, actor common.AdminActor, key string,
+) (*query.CacheEntryQueryResult, error) {
+	result, err := s.getEntry(ctx, key)
+	s.record(ctx, actor, ActionGetEntry, key, err)
+	return result, err
+}
+
+func (s *Service) Purge(
+	ctx context.Context, cmd *command.PurgeCacheCommand,
+) (*query.CachePurgeQueryResult, error) {
+	result, err := s.purge(ctx, cmd.Prefix, cmd.City)
+	s.record(ctx, cmd.Actor, ActionPurge, purgeTarget(cmd.Prefix, cmd.City), err)
+	return result, err
+}
+
+// Refresh drops everything cached about a city and fetches its weather again
+// through the provider chain.
+func (s *Service) Refresh(
+	ctx context.Context, cmd *command.RefreshCacheCommand,
+) (*query.CachePurgeQueryResult, error) {
+	result, err := s.refresh(ctx, cmd.City)
+	s.record(ctx, cmd.Actor, ActionRefresh, purgeTarget("", cmd.City), err)
+	return result, err
+}
+
+func (s *Service) listKeys(ctx context.Context, prefix string) (*query.CacheKeysQueryResult, error) {
+	if err := s.checkPrefix(prefix); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.store.Keys(ctx, prefix, "")
+	if err != nil {
+		return nil, pkgErrors.New(internalErrors.ErrInternal, "failed to list cache keys")
+	}
+
+	result := &query.CacheKeysQueryResult{Prefix: prefix, Keys: keys}
+	if len(keys) > maxListedKeys {
+		result.Keys = keys[:maxListedKeys]
+		result.Truncated = true
+	}
+	return result, nil
+}
+
+func (s *Service) getEntry(ctx context.Context, key string) (*query.CacheEntryQueryResult, error) {
+	prefix, _, _ := strings.Cut(key, ":")
+	if err := s.checkPrefix(prefix); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.store.Entry(ctx, key)
+	if err != nil {
+		return nil, pkgErrors.New(internalErrors.ErrInternal, "failed to read cache entry")
+	}
+	if entry == nil {
+		return nil, pkgErrors.New(internalErrors.ErrNotFound, "cache entry not found")
+	}
+	return &query.CacheEntryQueryResult{Entry: *entry}, nil
+}
+
+func (s *Service) purge(
+	ctx context.Context, prefix, city string,
+) (*query.CachePurgeQueryResult, error) {
+	if prefix == "" && strings.TrimSpace(city) == "" {
+		return nil, pkgErrors.New(internalErrors.ErrInvalidInput, "prefix or city is required")
+	}
+
+	prefixes := s.prefixes
+	if prefix != "" {
+		if err := s.checkPrefix(prefix); err != nil {
+			return nil, err
+		}
+		prefixes = []string{prefix}
+	}
+
+	var keys []string
+	for _, prefix := range prefixes {
+		found, err := s.store.Keys(ctx, prefix, city)
+		if err != nil {
+			return nil, pkgErrors.New(internalErrors.ErrInternal, "failed to list cache keys")
+		}
+		keys = append(keys, found...)
+	}
+
+	deleted, err := s.store.Delete(ctx, keys)
+	if err != nil {
+		return nil, pkgErrors.New(internalErrors.ErrInternal, "failed to purge cache entries")
+	}
+	return &query.CachePurgeQueryResult{Deleted: deleted}, nil
+}
+
+func (s *Service) refresh(ctx context.Context, city string) (*query.CachePurgeQueryResult, error) {
+	if strings.TrimSpace(city) == "" {
+		return nil, pkgErrors.New(internalErrors.ErrInvalidInput, "city is required")
+	}
+
+	result, err := s.purge(ctx, "", city)
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentErr := s.weather.GetWeather(ctx, city)
+	_, dailyErr := s.weather.GetDailyForecast(ctx, city)
+	_, hourlyErr := s.weather.GetHourlyForecast(ctx, city)
+	for _, err := range []error{currentErr, dailyErr, hourlyErr} {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) checkPrefix(prefix string) error {
+	if !slices.Contains(s.prefixes, prefix) {
+		return pkgErrors.New(
+			internalErrors.ErrInvalidInput,
+			fmt.Sprintf("unknown cache prefix %q", prefix),
+		)
+	}
+	return nil
+}
+
+func (s *Service) record(
+	ctx context.Context, actor common.AdminActor, action, target string, err error,
+) {
+	outcome := outcomeOK
+	if err != nil {
+		outcome = err.Error()
+	}
+
+	entry := &domain.AuditEntry{
+		Actor:      actor.Name,
+		RemoteAddr: actor.RemoteAddr,
+		Action:     action,
+		Target:     target,
+		Outcome:    outcome,
+		CreatedAt:  s.clock.Now(),
+	}
+	if auditErr := s.audit.Create(context.WithoutCancel(ctx), entry); auditErr != nil {
+		log.Printf("cache admin: failed to audit %s %s by %s: %v",
+			action, target, actor.Name, errors.Join(auditErr, err))
+	}
+}
+
+func purgeTarget(prefix, city string) string {
+	var parts []string
+	if prefix != "" {
+		parts = append(parts, "prefix="+prefix)
+	}
+	if city != "" {
+		parts = append(parts, "city="+city)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,147 @@
+//go:build unit
+// +build unit
+
+package cacheadmin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/application/command"
+	"weather-api/internal/application/common"
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/test/mocks"
+)
+
+var actor = common.AdminActor{Name: "ops", RemoteAddr: "10.0.0.1"}
+
+type storeStub struct {
+	entries map[string][]byte
+}
+
+func (s *storeStub) Keys(_ context.Context, prefix, city string) ([]string, error) {
+	var keys []string
+	for key := range s.entries {
+		if strings.HasPrefix(key, prefix+":") && strings.Contains(key, strings.ToLower(city)) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *storeStub) Entry(_ context.Context, key string) (*common.CacheEntry, error) {
+	value, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	return &common.CacheEntry{Key: key, Value: value, TTL: time.Minute}, nil
+}
+
+func (s *storeStub) Delete(_ context.Context, keys []string) (int, error) {
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+	return len(keys), nil
+}
+
+type auditStub struct {
+	entries []domain.AuditEntry
+}
+
+func (a *auditStub) Create(_ context.Context, entry *domain.AuditEntry) error {
+	a.entries = append(a.entries, *entry)
+	return nil
+}
+
+type clockStub struct{}
+
+func (clockStub) Now() time.Time {
+	return time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)
+}
+
+func newTestService() (*Service, *storeStub, *mocks.MockWeatherRepository, *auditStub) {
+	store := &storeStub{entries: map[string][]byte{
+		"weather-api:current:kyiv": []byte(`{}`),
+		"weather-api:kyiv:daily":   []byte(`{}`),
+		"open-meteo:current:kyiv":  []byte(`{}`),
+		"open-meteo:current:lviv":  []byte(`{}`),
+	}}
+	weatherRepo := new(mocks.MockWeatherRepository)
+	audit := &auditStub{}
+	service := NewService(store, weatherRepo, audit, clockStub{},
+		[]string{"weather-api", "open-meteo", "geo-coding"})
+	return service, store, weatherRepo, audit
+}
+
+func TestService_ListKeys_RejectsUnknownPrefix(t *testing.T) {
+	service, _, _, audit := newTestService()
+
+	_, err := service.ListKeys(context.Background(), actor, "session")
+
+	assert.ErrorIs(t, err, internalErrors.ErrInvalidInput)
+	require.Len(t, audit.entries, 1)
+	assert.Equal(t, ActionListKeys, audit.entries[0].Action)
+	assert.NotEqual(t, outcomeOK, audit.entries[0].Outcome)
+}
+
+func TestService_GetEntry_NotFound(t *testing.T) {
+	service, _, _, _ := newTestService()
+
+	_, err := service.GetEntry(context.Background(), actor, "open-meteo:current:odesa")
+
+	assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+}
+
+func TestService_Purge_ByCityAcrossPrefixes(t *testing.T) {
+	service, store, _, audit := newTestService()
+
+	result, err := service.Purge(context.Background(), &command.PurgeCacheCommand{
+		Actor: actor,
+		City:  "Kyiv",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Deleted)
+	assert.Contains(t, store.entries, "open-meteo:current:lviv")
+	require.Len(t, audit.entries, 1)
+	assert.Equal(t, domain.AuditEntry{
+		Actor:      "ops",
+		RemoteAddr: "10.0.0.1",
+		Action:     ActionPurge,
+		Target:     "city=Kyiv",
+		Outcome:    outcomeOK,
+		CreatedAt:  clockStub{}.Now(),
+	}, audit.entries[0])
+}
+
+func TestService_Purge_RequiresPrefixOrCity(t *testing.T) {
+	service, _, _, _ := newTestService()
+
+	_, err := service.Purge(context.Background(), &command.PurgeCacheCommand{Actor: actor})
+
+	assert.ErrorIs(t, err, internalErrors.ErrInvalidInput)
+}
+
+func TestService_Refresh_PurgesAndRefetches(t *testing.T) {
+	service, store, weatherRepo, _ := newTestService()
+	weatherRepo.On("GetWeather", mock.Anything, "Kyiv").Return(&domain.Weather{}, nil)
+	weatherRepo.On("GetDailyForecast", mock.Anything, "Kyiv").Return(&domain.WeatherDaily{}, nil)
+	weatherRepo.On("GetHourlyForecast", mock.Anything, "Kyiv").Return(&domain.WeatherHourly{}, nil)
+
+	result, err := service.Refresh(context.Background(), &command.RefreshCacheCommand{
+		Actor: actor,
+		City:  "Kyiv",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Deleted)
+	assert.Len(t, store.entries, 1)
+	weatherRepo.AssertExpectations(t)
+}
@@ -0,0 +1,162 @@
+package manage
+
+import (
+	"context"
+	"time"
+
+	"weather-api/internal/application/command"
+	"weather-api/internal/application/query"
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+type Repository interface {
+	FindByEmail(ctx context.Context, email string) ([]*domain.Subscription, error)
+	FindByIDAndEmail(ctx context.Context, id uint, email string) (*domain.Subscription, error)
+	ExistByLookup(ctx context.Context, lookup *domain.SubscriptionLookup) (bool, error)
+	Update(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type LinkNotifier interface {
+	NotifyManageLink(email, session string, expiresAt time.Time) error
+}
+
+// Service lets a subscriber manage all subscriptions of their address through
+// a magic link. Every action other than requesting the link needs a session
+// issued by Sessions.
+type Service struct {
+	repository Repository
+	sessions   *Sessions
+	notifier   LinkNotifier
+}
+
+func NewService(repository Repository, sessions *Sessions, notifier LinkNotifier) *Service {
+	return &Service{
+		repository: repository,
+		sessions:   sessions,
+		notifier:   notifier,
+	}
+}
+
+// RequestLink emails a management link to the address. Addresses without
+// subscriptions get no email but the same answer, so the endpoint does not
+// reveal who is subscribed.
+func (s *Service) RequestLink(ctx context.Context, email string) error {
+	if !s.sessions.Enabled() {
+		return pkgErrors.New(
+			internalErrors.ErrServiceUnavailable, "subscription management is not configured",
+		)
+	}
+
+	subscriptions, err := s.repository.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	session, expiresAt := s.sessions.Issue(email)
+	return s.notifier.NotifyManageLink(email, session, expiresAt)
+}
+
+func (s *Service) List(
+	ctx context.Context, session string,
+) (*query.ManagedSubscriptionsQueryResult, error) {
+	email, err := s.sessions.Verify(session)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.repository.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return &query.ManagedSubscriptionsQueryResult{Email: email, Subscriptions: subscriptions}, nil
+}
+
+func (s *Service) Edit(
+	ctx context.Context, cmd *command.EditSubscriptionCommand,
+) (*domain.Subscription, error) {
+	subscription, err := s.owned(ctx, cmd.Session, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	frequency := domain.Frequency(cmd.Frequency)
+	if frequency != "" && frequency != subscription.Frequency {
+		if err := s.ensureAvailable(ctx, subscription, frequency); err != nil {
+			return nil, err
+		}
+	}
+
+	err = subscription.Reschedule(frequency, domain.Delivery{
+		Time:     cmd.DeliveryTime,
+		Timezone: cmd.Timezone,
+		Schedule: cmd.Schedule,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.Update(ctx, subscription)
+}
+
+func (s *Service) SetPaused(ctx context.Context, session string, id uint, paused bool) error {
+	subscription, err := s.owned(ctx, session, id)
+	if err != nil {
+		return err
+	}
+
+	subscription.SetPaused(paused)
+	_, err = s.repository.Update(ctx, subscription)
+	return err
+}
+
+func (s *Service) Delete(ctx context.Context, session string, id uint) error {
+	subscription, err := s.owned(ctx, session, id)
+	if err != nil {
+		return err
+	}
+	return s.repository.Delete(ctx, subscription.ID)
+}
+
+// owned returns the subscription if it belongs to the session's address.
+// Subscriptions of other addresses are reported as not found.
+func (s *Service) owned(
+	ctx context.Context, session string, id uint,
+) (*domain.Subscription, error) {
+	email, err := s.sessions.Verify(session)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.repository.FindByIDAndEmail(ctx, id, email)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, pkgErrors.New(internalErrors.ErrNotFound, "Subscription not found")
+	}
+	return subscription, nil
+}
+
+func (s *Service) ensureAvailable(
+	ctx context.Context, subscription *domain.Subscription, frequency domain.Frequency,
+) error {
+	exists, err := s.repository.ExistByLookup(ctx, &domain.SubscriptionLookup{
+		Email:     subscription.Email,
+		City:      subscription.City,
+		Frequency: frequency,
+	})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return pkgErrors.New(
+			internalErrors.ErrConflict, "Already subscribed to this city with that frequency",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+//go:build unit
+// +build unit
+
+package manage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/application/command"
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/test/mocks"
+)
+
+const testEmail = "user@example.com"
+
+func newTestService(
+	t *testing.T,
+) (*Service, *mocks.MockSubscriptionRepository, *mocks.MockNotifier, string) {
+	t.Helper()
+	repo := &mocks.MockSubscriptionRepository{}
+	notifier := &mocks.MockNotifier{}
+	sessions := NewSessions("secret", time.Hour, &clockStub{now: time.Now()})
+	session, _ := sessions.Issue(testEmail)
+	return NewService(repo, sessions, notifier), repo, notifier, session
+}
+
+func TestService_RequestLink(t *testing.T) {
+	service, repo, notifier, _ := newTestService(t)
+	ctx := context.Background()
+	repo.On("FindByEmail", ctx, testEmail).
+		Return([]*domain.Subscription{{ID: 1, Email: testEmail}}, nil)
+	repo.On("FindByEmail", ctx, "nobody@example.com").Return([]*domain.Subscription{}, nil)
+	notifier.On("NotifyManageLink", testEmail, mock.AnythingOfType("string"),
+		mock.AnythingOfType("time.Time")).Return(nil)
+
+	require.NoError(t, service.RequestLink(ctx, testEmail))
+	require.NoError(t, service.RequestLink(ctx, "nobody@example.com"))
+
+	notifier.AssertNumberOfCalls(t, "NotifyManageLink", 1)
+}
+
+func TestService_RequestLink_NotConfigured(t *testing.T) {
+	service := NewService(&mocks.MockSubscriptionRepository{},
+		NewSessions("", time.Hour, &clockStub{}), &mocks.MockNotifier{})
+
+	err := service.RequestLink(context.Background(), testEmail)
+
+	assert.ErrorIs(t, err, internalErrors.ErrServiceUnavailable)
+}
+
+func TestService_List_RequiresSession(t *testing.T) {
+	service, _, _, _ := newTestService(t)
+
+	_, err := service.List(context.Background(), "forged")
+
+	assert.ErrorIs(t, err, internalErrors.ErrUnauthorized)
+}
+
+func TestService_Edit(t *testing.T) {
+	service, repo, _, session := newTestService(t)
+	ctx := context.Background()
+	subscription := &domain.Subscription{
+		ID: 7, Email: testEmail, City: "Kyiv", Frequency: domain.FrequencyHourly,
+		DeliveryTime: "08:00", Timezone: "Europe/Kyiv",
+	}
+	repo.On("FindByIDAndEmail", ctx, uint(7), testEmail).Return(subscription, nil)
+	repo.On("ExistByLookup", ctx, &domain.SubscriptionLookup{
+		Email: testEmail, City: "Kyiv", Frequency: domain.FrequencyCustom,
+	}).Return(false, nil)
+	repo.On("Update", ctx, subscription).Return(subscription, nil)
+
+	updated, err := service.Edit(ctx, &command.EditSubscriptionCommand{
+		Session: session, ID: 7, Frequency: "custom", Schedule: "Weekdays at 07:00",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.FrequencyCustom, updated.Frequency)
+	assert.Equal(t, "weekdays at 07:00", updated.Schedule)
+	assert.Equal(t, "Europe/Kyiv", updated.Timezone)
+}
+
+func TestService_Edit_Conflict(t *testing.T) {
+	service, repo, _, session := newTestService(t)
+	ctx := context.Background()
+	subscription := &domain.Subscription{
+		ID: 7, Email: testEmail, City: "Kyiv", Frequency: domain.FrequencyHourly,
+	}
+	repo.On("FindByIDAndEmail", ctx, uint(7), testEmail).Return(subscription, nil)
+	repo.On("ExistByLookup", ctx, mock.Anything).Return(true, nil)
+
+	_, err := service.Edit(ctx, &command.EditSubscriptionCommand{
+		Session: session, ID: 7, Frequency: "daily",
+	})
+
+	assert.ErrorIs(t, err, internalErrors.ErrConflict)
+	assert.Equal(t, domain.FrequencyHourly, subscription.Frequency)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestService_SetPaused(t *testing.T) {
+	service, repo, _, session := newTestService(t)
+	ctx := context.Background()
+	subscription := &domain.Subscription{ID: 7, Email: testEmail}
+	repo.On("FindByIDAndEmail", ctx, uint(7), testEmail).Return(subscription, nil)
+	repo.On("Update", ctx, subscription).Return(subscription, nil)
+
+	require.NoError(t, service.SetPaused(ctx, session, 7, true))
+
+	assert.True(t, subscription.Paused)
+}
+
+func TestService_Delete_OtherAddress(t *testing.T) {
+	service, repo, _, session := newTestService(t)
+	ctx := context.Background()
+	repo.On("FindByIDAndEmail", ctx, uint(8), testEmail).Return(nil, nil)
+
+	err := service.Delete(ctx, session, 8)
+
+	assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+	repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
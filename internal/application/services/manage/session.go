@@ -0,0 +1,86 @@
+package manage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+const sessionPurpose = "manage:"
+
+type Clock interface {
+	Now() time.Time
+}
+
+// Sessions issues and verifies the signed tokens of magic links. A token
+// carries the email address and its expiry, signed with HMAC-SHA256, so no
+// server-side state is needed.
+type Sessions struct {
+	secret []byte
+	ttl    time.Duration
+	clock  Clock
+}
+
+func NewSessions(secret string, ttl time.Duration, clock Clock) *Sessions {
+	return &Sessions{secret: []byte(secret), ttl: ttl, clock: clock}
+}
+
+// Enabled reports whether a secret is configured; without one every token is
+// rejected.
+func (s *Sessions) Enabled() bool {
+	return len(s.secret) > 0
+}
+
+func (s *Sessions) Issue(email string) (string, time.Time) {
+	expiresAt := s.clock.Now().Add(s.ttl).Truncate(time.Second)
+	payload := strconv.FormatInt(expiresAt.Unix(), 10) + ":" + email
+
+	return encode([]byte(payload)) + "." + encode(s.sign(payload)), expiresAt
+}
+
+// Verify returns the email address the token was issued for.
+func (s *Sessions) Verify(token string) (string, error) {
+	invalid := pkgErrors.New(internalErrors.ErrUnauthorized, "invalid or expired session")
+	if !s.Enabled() {
+		return "", invalid
+	}
+
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return "", invalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", invalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.sign(string(payload))) {
+		return "", invalid
+	}
+
+	expiry, email, found := strings.Cut(string(payload), ":")
+	if !found {
+		return "", invalid
+	}
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || !s.clock.Now().Before(time.Unix(expiresAt, 0)) {
+		return "", invalid
+	}
+	return email, nil
+}
+
+func (s *Sessions) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(sessionPurpose + payload))
+	return mac.Sum(nil)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
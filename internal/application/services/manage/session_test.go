@@ -0,0 +1,71 @@
+//go:build unit
+// +build unit
+
+package manage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalErrors "weather-api/internal/errors"
+)
+
+type clockStub struct {
+	now time.Time
+}
+
+func (c *clockStub) Now() time.Time {
+	return c.now
+}
+
+func TestSessions_IssueAndVerify(t *testing.T) {
+	clock := &clockStub{now: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)}
+	sessions := NewSessions("secret", 30*time.Minute, clock)
+
+	token, expiresAt := sessions.Issue("user@example.com")
+	assert.Equal(t, clock.now.Add(30*time.Minute), expiresAt)
+
+	email, err := sessions.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", email)
+
+	clock.now = expiresAt
+	_, err = sessions.Verify(token)
+	assert.ErrorIs(t, err, internalErrors.ErrUnauthorized)
+}
+
+func TestSessions_RejectsForgedTokens(t *testing.T) {
+	clock := &clockStub{now: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)}
+	sessions := NewSessions("secret", time.Hour, clock)
+	token, _ := sessions.Issue("user@example.com")
+	payload, signature, _ := strings.Cut(token, ".")
+	other, _ := sessions.Issue("other@example.com")
+	otherPayload, _, _ := strings.Cut(other, ".")
+
+	tests := map[string]string{
+		"empty":             "",
+		"no signature":      payload,
+		"swapped payload":   otherPayload + "." + signature,
+		"other secret":      mustIssue(NewSessions("other", time.Hour, clock)),
+		"garbled signature": payload + ".!!!",
+	}
+	for name, forged := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := sessions.Verify(forged)
+
+			assert.ErrorIs(t, err, internalErrors.ErrUnauthorized)
+		})
+	}
+
+	_, err := NewSessions("", time.Hour, clock).Verify(token)
+	assert.ErrorIs(t, err, internalErrors.ErrUnauthorized)
+}
+
+func mustIssue(sessions *Sessions) string {
+	token, _ := sessions.Issue("user@example.com")
+	return token
+}
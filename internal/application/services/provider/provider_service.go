@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"context"
+
+	"weather-api/internal/application/common"
+	"weather-api/internal/application/query"
+)
+
+type StatusReader interface {
+	Statuses() []common.ProviderStatus
+}
+
+type Service struct {
+	reader StatusReader
+}
+
+func NewService(reader StatusReader) *Service {
+	return &Service{reader: reader}
+}
+
+func (s *Service) GetStatus(_ context.Context) (*query.ProviderStatusQueryResult, error) {
+	return &query.ProviderStatusQueryResult{Providers: s.reader.Statuses()}, nil
+}
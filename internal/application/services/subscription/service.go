@@ -2,8 +2,11 @@ package subscription
 
 import (
 	"context"
+	"log"
+	"strings"
 
 	"weather-api/internal/application/command"
+	"weather-api/internal/application/query"
 	"weather-api/internal/domain"
 	internalErrors "weather-api/internal/errors"
 	pkgErrors "weather-api/pkg/errors"
@@ -25,9 +28,14 @@ type CityValidator interface {
 	Validate(ctx context.Context, city string) (*string, error)
 }
 
+type LocationResolver interface {
+	Resolve(ctx context.Context, city string) (*domain.Location, error)
+}
+
 type Service struct {
 	repository Repository
 	validator  CityValidator
+	locations  LocationResolver
 	notifier   ConfirmationNotifier
 	host       string
 }
@@ -35,10 +43,15 @@ type Service struct {
 func NewService(
 	repository Repository,
 	validator CityValidator,
+	locations LocationResolver,
 	notifier ConfirmationNotifier, host string,
 ) *Service {
 	return &Service{
-		repository: repository, validator: validator, notifier: notifier, host: host,
+		repository: repository,
+		validator:  validator,
+		locations:  locations,
+		notifier:   notifier,
+		host:       host,
 	}
 }
 
@@ -86,6 +99,50 @@ func (s *Service) Confirm(ctx context.Context, token string) error {
 	return err
 }
 
+// Update changes the subscription the token belongs to. A new city is
+// validated as on subscribe and, unless a timezone is given, brings its own
+// timezone. Only a new email address needs to be confirmed again.
+func (s *Service) Update(
+	ctx context.Context, updateCommand *command.UpdateSubscriptionCommand,
+) (*query.SubscriptionUpdateQueryResult, error) {
+	subscription, err := s.repository.FindByToken(ctx, updateCommand.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription == nil {
+		return nil, pkgErrors.New(internalErrors.ErrNotFound, "Token not found")
+	}
+
+	changes, err := s.changes(ctx, subscription, updateCommand)
+	if err != nil {
+		return nil, err
+	}
+	previous := subscription.Lookup()
+	reconfirm, err := subscription.Update(changes)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureAvailable(ctx, previous, subscription.Lookup()); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repository.Update(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+	if reconfirm {
+		if err := s.notifier.NotifyConfirmation(updated); err != nil {
+			return nil, err
+		}
+	}
+
+	return &query.SubscriptionUpdateQueryResult{
+		Subscription:     updated,
+		ConfirmationSent: reconfirm,
+	}, nil
+}
+
 func (s *Service) Unsubscribe(ctx context.Context, token string) error {
 	subscription, err := s.repository.FindByToken(ctx, token)
 	if err != nil {
@@ -119,6 +176,11 @@ func (s *Service) createSubscription(
 		subscribeCommand.Email,
 		subscribeCommand.City,
 		domain.Frequency(subscribeCommand.Frequency),
+		domain.Delivery{
+			Time:     subscribeCommand.DeliveryTime,
+			Timezone: s.timezone(ctx, subscribeCommand.Timezone, subscribeCommand.City),
+			Schedule: subscribeCommand.Schedule,
+		},
 	)
 	if err != nil {
 		return nil, err
@@ -131,3 +193,69 @@ func (s *Service) createSubscription(
 
 	return savedSubscription, nil
 }
+
+// changes validates a new city and picks up its timezone when none is given.
+func (s *Service) changes(
+	ctx context.Context,
+	subscription *domain.Subscription,
+	updateCommand *command.UpdateSubscriptionCommand,
+) (domain.SubscriptionChanges, error) {
+	city, timezone := "", updateCommand.Timezone
+	if updateCommand.City != "" {
+		validatedCity, err := s.validator.Validate(ctx, updateCommand.City)
+		if err != nil {
+			return domain.SubscriptionChanges{}, err
+		}
+		city = *validatedCity
+		if city != subscription.City {
+			timezone = s.timezone(ctx, updateCommand.Timezone, city)
+		}
+	}
+
+	return domain.SubscriptionChanges{
+		Email:     updateCommand.Email,
+		City:      city,
+		Frequency: domain.Frequency(updateCommand.Frequency),
+		Delivery: domain.Delivery{
+			Time:     updateCommand.DeliveryTime,
+			Timezone: timezone,
+			Schedule: updateCommand.Schedule,
+		},
+	}, nil
+}
+
+// ensureAvailable keeps (email, city, frequency) unique when an update moves
+// the subscription to another one.
+func (s *Service) ensureAvailable(
+	ctx context.Context, previous, next *domain.SubscriptionLookup,
+) error {
+	if strings.EqualFold(previous.Email, next.Email) &&
+		previous.City == next.City && previous.Frequency == next.Frequency {
+		return nil
+	}
+
+	exists, err := s.repository.ExistByLookup(ctx, next)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return pkgErrors.New(internalErrors.ErrConflict, "Email already subscribed")
+	}
+	return nil
+}
+
+// timezone is the one the subscriber asked for, or else the city's. Without a
+// resolver, or for cities it cannot resolve, it is empty so the default or
+// current timezone is kept rather than failing the request.
+func (s *Service) timezone(ctx context.Context, requested, city string) string {
+	if requested != "" || s.locations == nil {
+		return requested
+	}
+
+	location, err := s.locations.Resolve(ctx, city)
+	if err != nil || location == nil {
+		log.Printf("subscription: no timezone for %q: %v", city, err)
+		return ""
+	}
+	return location.Timezone
+}
@@ -11,6 +11,7 @@ import (
 	"weather-api/internal/domain"
 	internalErrors "weather-api/internal/errors"
 	"weather-api/internal/test/mocks"
+	"weather-api/internal/test/stubs"
 	pkgErrors "weather-api/pkg/errors"
 
 	"github.com/stretchr/testify/assert"
@@ -27,9 +28,10 @@ func TestSubscriptionService_Subscribe_Success(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	cmd := &command.SubscribeCommand{
@@ -69,9 +71,10 @@ func TestSubscriptionService_Subscribe_InvalidCity(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	cmd := &command.SubscribeCommand{
@@ -98,9 +101,10 @@ func TestSubscriptionService_Subscribe_AlreadyExists(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	cmd := &command.SubscribeCommand{
@@ -136,9 +140,10 @@ func TestSubscriptionService_Subscribe_RepositoryError(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	cmd := &command.SubscribeCommand{
@@ -175,9 +180,10 @@ func TestSubscriptionService_Subscribe_InvalidFrequency(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	cmd := &command.SubscribeCommand{
@@ -214,9 +220,10 @@ func TestSubscriptionService_Subscribe_CreateError(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	cmd := &command.SubscribeCommand{
@@ -254,9 +261,10 @@ func TestSubscriptionService_Subscribe_EmailError(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	cmd := &command.SubscribeCommand{
@@ -296,13 +304,103 @@ func TestSubscriptionService_Subscribe_EmailError(t *testing.T) {
 	mockNotifier.AssertExpectations(t)
 }
 
+func TestSubscriptionService_Subscribe_Delivery(t *testing.T) {
+	tests := []struct {
+		name         string
+		cmd          command.SubscribeCommand
+		resolveErr   error
+		wantTime     string
+		wantTimezone string
+	}{
+		{
+			name:         "timezone derived from city",
+			wantTime:     domain.DefaultDeliveryTime,
+			wantTimezone: "Europe/Kyiv",
+		},
+		{
+			name:         "requested delivery kept",
+			cmd:          command.SubscribeCommand{DeliveryTime: "07:30", Timezone: "America/New_York"},
+			wantTime:     "07:30",
+			wantTimezone: "America/New_York",
+		},
+		{
+			name:         "unresolved city uses default timezone",
+			resolveErr:   pkgErrors.New(internalErrors.ErrServiceUnavailable, "geocoding down"),
+			wantTime:     domain.DefaultDeliveryTime,
+			wantTimezone: domain.DefaultTimezone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockSubscriptionRepository)
+			mockValidator := new(mocks.MockCityValidator)
+			mockNotifier := new(mocks.MockNotifier)
+			locations := stubs.NewLocationResolverStub()
+			if tt.resolveErr != nil {
+				locations.ResolveFn = func(string) (*domain.Location, error) {
+					return nil, tt.resolveErr
+				}
+			}
+
+			service := NewService(mockRepo, mockValidator, locations, mockNotifier, testHost)
+
+			ctx := context.Background()
+			cmd := tt.cmd
+			cmd.Email = "test@example.com"
+			cmd.City = "kyiv"
+			cmd.Frequency = "daily"
+
+			mockValidator.On("Validate", ctx, "kyiv").Return("Kyiv", nil)
+			mockRepo.On("ExistByLookup", ctx, mock.Anything).Return(false, nil)
+			mockRepo.On("Create", ctx, mock.MatchedBy(func(sub *domain.Subscription) bool {
+				return sub.DeliveryTime == tt.wantTime && sub.Timezone == tt.wantTimezone
+			})).Return(&domain.Subscription{}, nil)
+			mockNotifier.On("NotifyConfirmation", mock.Anything).Return(nil)
+
+			err := service.Subscribe(ctx, &cmd)
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubscriptionService_Subscribe_InvalidDelivery(t *testing.T) {
+	mockRepo := new(mocks.MockSubscriptionRepository)
+	mockValidator := new(mocks.MockCityValidator)
+	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
+
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, testHost)
+
+	ctx := context.Background()
+	cmd := &command.SubscribeCommand{
+		Email:     "test@example.com",
+		City:      "kyiv",
+		Frequency: "daily",
+		Timezone:  "Mars/Olympus_Mons",
+	}
+
+	mockValidator.On("Validate", ctx, "kyiv").Return("Kyiv", nil)
+	mockRepo.On("ExistByLookup", ctx, mock.Anything).Return(false, nil)
+
+	err := service.Subscribe(ctx, cmd)
+
+	apiErr, ok := pkgErrors.IsApiError(err)
+	assert.True(t, ok)
+	assert.Equal(t, internalErrors.ErrInvalidInput, apiErr.Base)
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
 func TestSubscriptionService_Confirm_Success(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	token := validToken
@@ -340,9 +438,10 @@ func TestSubscriptionService_Confirm_TokenNotFound(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	token := "invalid-token"
@@ -365,9 +464,10 @@ func TestSubscriptionService_Confirm_RepositoryError(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	token := validToken
@@ -391,9 +491,10 @@ func TestSubscriptionService_Confirm_UpdateError(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	token := validToken
@@ -427,9 +528,10 @@ func TestSubscriptionService_Unsubscribe_Success(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	token := validToken
@@ -456,9 +558,10 @@ func TestSubscriptionService_Unsubscribe_TokenNotFound(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	token := "invalid-token"
@@ -481,9 +584,10 @@ func TestSubscriptionService_Unsubscribe_RepositoryError(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	token := validToken
@@ -507,9 +611,10 @@ func TestSubscriptionService_Unsubscribe_DeleteError(t *testing.T) {
 	mockRepo := new(mocks.MockSubscriptionRepository)
 	mockValidator := new(mocks.MockCityValidator)
 	mockNotifier := new(mocks.MockNotifier)
+	locations := stubs.NewLocationResolverStub()
 	host := testHost
 
-	service := NewService(mockRepo, mockValidator, mockNotifier, host)
+	service := NewService(mockRepo, mockValidator, locations, mockNotifier, host)
 
 	ctx := context.Background()
 	token := validToken
@@ -535,3 +640,141 @@ func TestSubscriptionService_Unsubscribe_DeleteError(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func confirmedBerlinSubscription() *domain.Subscription {
+	return &domain.Subscription{
+		ID:           1,
+		Email:        "test@example.com",
+		City:         ValidatedCity,
+		Frequency:    domain.FrequencyHourly,
+		DeliveryTime: domain.DefaultDeliveryTime,
+		Timezone:     "Europe/Berlin",
+		Token:        validToken,
+		Confirmed:    true,
+	}
+}
+
+func TestSubscriptionService_Update_FrequencyKeepsConfirmation(t *testing.T) {
+	mockRepo := new(mocks.MockSubscriptionRepository)
+	mockNotifier := new(mocks.MockNotifier)
+	service := NewService(mockRepo, new(mocks.MockCityValidator),
+		stubs.NewLocationResolverStub(), mockNotifier, testHost)
+	ctx := context.Background()
+	subscription := confirmedBerlinSubscription()
+
+	mockRepo.On("FindByToken", ctx, validToken).Return(subscription, nil)
+	mockRepo.On("ExistByLookup", ctx, &domain.SubscriptionLookup{
+		Email: "test@example.com", City: ValidatedCity, Frequency: domain.FrequencyDaily,
+	}).Return(false, nil)
+	mockRepo.On("Update", ctx, subscription).Return(subscription, nil)
+
+	result, err := service.Update(ctx, &command.UpdateSubscriptionCommand{
+		Token: validToken, Frequency: "daily", DeliveryTime: "07:30",
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.ConfirmationSent)
+	assert.Equal(t, domain.FrequencyDaily, result.Subscription.Frequency)
+	assert.Equal(t, "07:30", result.Subscription.DeliveryTime)
+	assert.True(t, result.Subscription.Confirmed)
+	assert.Equal(t, validToken, result.Subscription.Token)
+	mockNotifier.AssertNotCalled(t, "NotifyConfirmation", mock.Anything)
+}
+
+func TestSubscriptionService_Update_CityIsValidated(t *testing.T) {
+	mockRepo := new(mocks.MockSubscriptionRepository)
+	mockValidator := new(mocks.MockCityValidator)
+	service := NewService(mockRepo, mockValidator, stubs.NewLocationResolverStub(),
+		new(mocks.MockNotifier), testHost)
+	ctx := context.Background()
+	subscription := confirmedBerlinSubscription()
+
+	mockRepo.On("FindByToken", ctx, validToken).Return(subscription, nil)
+	mockValidator.On("Validate", ctx, "kyiv").Return("Kyiv", nil)
+	mockRepo.On("ExistByLookup", ctx, mock.Anything).Return(false, nil)
+	mockRepo.On("Update", ctx, subscription).Return(subscription, nil)
+
+	result, err := service.Update(ctx, &command.UpdateSubscriptionCommand{
+		Token: validToken, City: "kyiv",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Kyiv", result.Subscription.City)
+	assert.Equal(t, "Europe/Kyiv", result.Subscription.Timezone)
+	mockValidator.AssertExpectations(t)
+}
+
+func TestSubscriptionService_Update_InvalidCity(t *testing.T) {
+	mockRepo := new(mocks.MockSubscriptionRepository)
+	mockValidator := new(mocks.MockCityValidator)
+	service := NewService(mockRepo, mockValidator, stubs.NewLocationResolverStub(),
+		new(mocks.MockNotifier), testHost)
+	ctx := context.Background()
+	invalidCity := pkgErrors.New(internalErrors.ErrNotFound, "City not found")
+
+	mockRepo.On("FindByToken", ctx, validToken).Return(confirmedBerlinSubscription(), nil)
+	mockValidator.On("Validate", ctx, "Atlantis").Return(nil, invalidCity)
+
+	_, err := service.Update(ctx, &command.UpdateSubscriptionCommand{
+		Token: validToken, City: "Atlantis",
+	})
+
+	assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSubscriptionService_Update_EmailRequiresConfirmation(t *testing.T) {
+	mockRepo := new(mocks.MockSubscriptionRepository)
+	mockNotifier := new(mocks.MockNotifier)
+	service := NewService(mockRepo, new(mocks.MockCityValidator),
+		stubs.NewLocationResolverStub(), mockNotifier, testHost)
+	ctx := context.Background()
+	subscription := confirmedBerlinSubscription()
+
+	mockRepo.On("FindByToken", ctx, validToken).Return(subscription, nil)
+	mockRepo.On("ExistByLookup", ctx, &domain.SubscriptionLookup{
+		Email: "new@example.com", City: ValidatedCity, Frequency: domain.FrequencyHourly,
+	}).Return(false, nil)
+	mockRepo.On("Update", ctx, subscription).Return(subscription, nil)
+	mockNotifier.On("NotifyConfirmation", subscription).Return(nil)
+
+	result, err := service.Update(ctx, &command.UpdateSubscriptionCommand{
+		Token: validToken, Email: "new@example.com",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.ConfirmationSent)
+	assert.False(t, result.Subscription.Confirmed)
+	assert.NotEqual(t, validToken, result.Subscription.Token)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestSubscriptionService_Update_Conflict(t *testing.T) {
+	mockRepo := new(mocks.MockSubscriptionRepository)
+	service := NewService(mockRepo, new(mocks.MockCityValidator),
+		stubs.NewLocationResolverStub(), new(mocks.MockNotifier), testHost)
+	ctx := context.Background()
+
+	mockRepo.On("FindByToken", ctx, validToken).Return(confirmedBerlinSubscription(), nil)
+	mockRepo.On("ExistByLookup", ctx, mock.Anything).Return(true, nil)
+
+	_, err := service.Update(ctx, &command.UpdateSubscriptionCommand{
+		Token: validToken, Frequency: "daily",
+	})
+
+	assert.ErrorIs(t, err, internalErrors.ErrConflict)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSubscriptionService_Update_TokenNotFound(t *testing.T) {
+	mockRepo := new(mocks.MockSubscriptionRepository)
+	service := NewService(mockRepo, new(mocks.MockCityValidator),
+		stubs.NewLocationResolverStub(), new(mocks.MockNotifier), testHost)
+	ctx := context.Background()
+
+	mockRepo.On("FindByToken", ctx, "missing").Return(nil, nil)
+
+	_, err := service.Update(ctx, &command.UpdateSubscriptionCommand{Token: "missing"})
+
+	assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+}
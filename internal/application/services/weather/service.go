@@ -2,14 +2,18 @@ package weather
 
 import (
 	"context"
+	"fmt"
 
 	"weather-api/internal/application/common"
 	"weather-api/internal/application/query"
 	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
 )
 
 type Reader interface {
 	GetWeather(ctx context.Context, city string) (*domain.Weather, error)
+	GetHourlyTimeline(ctx context.Context, city string, hours int) (*domain.WeatherTimeline, error)
 }
 
 type Service struct {
@@ -30,10 +34,51 @@ func (s *Service) GetWeather(ctx context.Context, city string) (*query.WeatherQu
 	return &queryResult, nil
 }
 
+func (s *Service) GetHourlyForecast(
+	ctx context.Context, city string, hours int,
+) (*query.WeatherTimelineQueryResult, error) {
+	if hours < 1 || hours > domain.MaxTimelineHours {
+		return nil, pkgErrors.New(
+			internalErrors.ErrInvalidInput,
+			fmt.Sprintf("hours must be between 1 and %d", domain.MaxTimelineHours),
+		)
+	}
+
+	timeline, err := s.weatherRepository.GetHourlyTimeline(ctx, city, hours)
+	if err != nil {
+		return nil, err
+	}
+	return toWeatherTimelineQueryResult(timeline), nil
+}
+
+func toWeatherTimelineQueryResult(
+	timeline *domain.WeatherTimeline,
+) *query.WeatherTimelineQueryResult {
+	hours := make([]common.WeatherHourlyResult, 0, len(timeline.Hours))
+	for _, hour := range timeline.Hours {
+		hours = append(hours, common.WeatherHourlyResult{
+			Time:       hour.Time,
+			TempC:      hour.TempC,
+			WillItRain: hour.WillItRain,
+			ChanceRain: hour.ChanceRain,
+			WillItSnow: hour.WillItSnow,
+			ChanceSnow: hour.ChanceSnow,
+			Condition:  hour.Condition,
+			Icon:       hour.Icon,
+		})
+	}
+	return &query.WeatherTimelineQueryResult{
+		Location:  timeline.Location,
+		Hours:     hours,
+		FetchedAt: timeline.FetchedAt,
+	}
+}
+
 func toNewWeatherResult(weather *domain.Weather) *common.WeatherResult {
 	return &common.WeatherResult{
 		Temperature: weather.Temperature,
 		Humidity:    weather.Humidity,
 		Description: weather.Description,
+		FetchedAt:   weather.FetchedAt,
 	}
 }
@@ -59,3 +59,37 @@ func TestWeatherService_GetWeather_EmptyCity(t *testing.T) {
 	assert.Nil(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestWeatherService_GetHourlyForecast_Success(t *testing.T) {
+	mockRepo := new(mocks.MockWeatherRepository)
+	service := NewService(mockRepo)
+
+	timeline := &domain.WeatherTimeline{
+		Location: validatedCity,
+		Hours: []domain.WeatherHourly{
+			{Time: "2025-05-18 10:00", TempC: 18, ChanceRain: 40, Condition: "Cloudy"},
+			{Time: "2025-05-18 11:00", TempC: 19, ChanceRain: 70, WillItRain: true},
+		},
+	}
+	mockRepo.On("GetHourlyTimeline", mock.Anything, validatedCity, 2).Return(timeline, nil)
+
+	result, err := service.GetHourlyForecast(context.Background(), validatedCity, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, validatedCity, result.Location)
+	assert.Len(t, result.Hours, 2)
+	assert.Equal(t, 70, result.Hours[1].ChanceRain)
+	assert.True(t, result.Hours[1].WillItRain)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWeatherService_GetHourlyForecast_InvalidHours(t *testing.T) {
+	mockRepo := new(mocks.MockWeatherRepository)
+	service := NewService(mockRepo)
+
+	result, err := service.GetHourlyForecast(context.Background(), validatedCity, 0)
+
+	assert.ErrorIs(t, err, internalErrors.ErrInvalidInput)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "GetHourlyTimeline")
+}
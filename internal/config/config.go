@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/iamolegga/enviper"
@@ -16,14 +18,32 @@ const (
 	tagName        = "config"
 )
 
+const (
+	ProviderOpenMeteo        = "open-meteo"
+	ProviderWeatherAPI       = "weather-api"
+	ProviderGeoCoding        = "geo-coding"
+	ProviderWeatherAPISearch = "weather-api-search"
+)
+
+const (
+	RedisStandalone = "standalone"
+	RedisSentinel   = "sentinel"
+	RedisCluster    = "cluster"
+)
+
 type Config struct {
-	DB           DBConfig      `config:"db"`
-	Server       ServerConfig  `config:"server"`
-	Weather      WeatherConfig `config:"weather"`
-	Email        EmailConfig   `config:"email"`
-	OpenMeteoURL string        `config:"open_meteo_url"`
-	GeoCodingURL string        `config:"geo_coding_url"`
-	Redis        RedisConfig   `config:"redis"`
+	DB           DBConfig        `config:"db"`
+	Server       ServerConfig    `config:"server"`
+	Weather      WeatherConfig   `config:"weather"`
+	Email        EmailConfig     `config:"email"`
+	OpenMeteoURL string          `config:"open_meteo_url"`
+	GeoCodingURL string          `config:"geo_coding_url"`
+	Redis        RedisConfig     `config:"redis"`
+	Providers    ProvidersConfig `config:"providers"`
+	Cache        CacheConfig     `config:"cache"`
+	Jobs         JobsConfig      `config:"jobs"`
+	Admin        AdminConfig     `config:"admin"`
+	Manage       ManageConfig    `config:"manage"`
 }
 
 type DBConfig struct {
@@ -52,10 +72,150 @@ type EmailConfig struct {
 	From     string `config:"from"`
 }
 
+// RedisConfig selects how to reach Redis. Standalone mode connects to
+// Address; Sentinel mode asks the sentinels in Addresses for MasterName; Cluster
+// mode uses Addresses as seed nodes and only supports DB 0.
 type RedisConfig struct {
-	Address  string `config:"address"`
-	Password string `config:"password"`
-	DB       int    `config:"db"`
+	Mode             string         `config:"mode"`
+	Address          string         `config:"address"`
+	Addresses        []string       `config:"addresses"`
+	MasterName       string         `config:"master_name"`
+	Username         string         `config:"username"`
+	Password         string         `config:"password"`
+	SentinelPassword string         `config:"sentinel_password"`
+	DB               int            `config:"db"`
+	TLS              RedisTLSConfig `config:"tls"`
+}
+
+type RedisTLSConfig struct {
+	Enabled            bool   `config:"enabled"`
+	CAFile             string `config:"ca_file"`
+	CertFile           string `config:"cert_file"`
+	KeyFile            string `config:"key_file"`
+	ServerName         string `config:"server_name"`
+	InsecureSkipVerify bool   `config:"insecure_skip_verify"`
+}
+
+// AdminConfig holds the admin API credentials. Every entry of Tokens reads
+// "<actor>:<token>", so the audit log names the operator a token belongs to;
+// the single legacy Token acts for the actor "admin".
+type AdminConfig struct {
+	Token  string   `config:"token"`
+	Tokens []string `config:"tokens"`
+
+	// Credentials maps every token to its actor. LoadConfig fills it in.
+	Credentials map[string]string `config:"-"`
+}
+
+const defaultAdminActor = "admin"
+
+func (c *AdminConfig) parseCredentials() error {
+	c.Credentials = make(map[string]string, len(c.Tokens)+1)
+	if c.Token != "" {
+		c.Credentials[c.Token] = defaultAdminActor
+	}
+	for i, entry := range c.Tokens {
+		actor, token, _ := strings.Cut(entry, ":")
+		actor, token = strings.TrimSpace(actor), strings.TrimSpace(token)
+		if actor == "" || token == "" {
+			return fmt.Errorf("admin token %d must have the form <actor>:<token>", i+1)
+		}
+		if _, taken := c.Credentials[token]; taken {
+			return fmt.Errorf("admin token %d is already used by another actor", i+1)
+		}
+		c.Credentials[token] = actor
+	}
+	return nil
+}
+
+// ManageConfig signs the magic links of the subscription management page,
+// which stays closed while Secret is unset.
+type ManageConfig struct {
+	Secret  string        `config:"secret"`
+	LinkTTL time.Duration `config:"link_ttl"`
+}
+
+type JobsConfig struct {
+	WarmupLead        time.Duration `config:"warmup_lead"`
+	WarmupConcurrency int           `config:"warmup_concurrency"`
+}
+
+type CacheConfig struct {
+	Local                LocalCacheConfig    `config:"local"`
+	Fallback             FallbackCacheConfig `config:"fallback"`
+	HealthCheckInterval  time.Duration       `config:"health_check_interval"`
+	Codec                string              `config:"codec"`
+	CompressAbove        int                 `config:"compress_above"`
+	LockTTL              time.Duration       `config:"lock_ttl"`
+	NegativeTTL          time.Duration       `config:"negative_ttl"`
+	StaleWhileRevalidate time.Duration       `config:"stale_while_revalidate"`
+	StaleIfError         time.Duration       `config:"stale_if_error"`
+}
+
+type LocalCacheConfig struct {
+	Enabled bool          `config:"enabled"`
+	Size    int           `config:"size"`
+	TTL     time.Duration `config:"ttl"`
+}
+
+// FallbackCacheConfig sizes the in-memory cache used while Redis is down.
+type FallbackCacheConfig struct {
+	Size int           `config:"size"`
+	TTL  time.Duration `config:"ttl"`
+}
+
+type ProvidersConfig struct {
+	WeatherChain     []string       `config:"weather_chain"`
+	ValidationChain  []string       `config:"validation_chain"`
+	SearchChain      []string       `config:"search_chain"`
+	NoFallbackOn     []string       `config:"no_fallback_on"`
+	OpenMeteo        ProviderConfig `config:"open_meteo"`
+	WeatherAPI       ProviderConfig `config:"weather_api"`
+	GeoCoding        ProviderConfig `config:"geo_coding"`
+	WeatherAPISearch ProviderConfig `config:"weather_api_search"`
+	Hedge            HedgeConfig    `config:"hedge"`
+	Location         LocationConfig `config:"location"`
+}
+
+type LocationConfig struct {
+	CachePrefix string        `config:"cache_prefix"`
+	CacheTTL    time.Duration `config:"cache_ttl"`
+}
+
+type HedgeConfig struct {
+	Enabled  bool          `config:"enabled"`
+	Delay    time.Duration `config:"delay"`
+	Quantile float64       `config:"quantile"`
+	Window   int           `config:"window"`
+	MinDelay time.Duration `config:"min_delay"`
+}
+
+type ProviderConfig struct {
+	Enabled       bool          `config:"enabled"`
+	URL           string        `config:"url"`
+	APIKey        string        `config:"api_key"`
+	Timeout       time.Duration `config:"timeout"`
+	RetryAttempts int           `config:"retry_attempts"`
+	CachePrefix   string        `config:"cache_prefix"`
+	CacheTTL      time.Duration `config:"cache_ttl"`
+	QuotaGroup    string        `config:"quota_group"`
+	HourlyQuota   int           `config:"hourly_quota"`
+	DailyQuota    int           `config:"daily_quota"`
+}
+
+func (c ProvidersConfig) Provider(name string) (ProviderConfig, bool) {
+	switch name {
+	case ProviderOpenMeteo:
+		return c.OpenMeteo, true
+	case ProviderWeatherAPI:
+		return c.WeatherAPI, true
+	case ProviderGeoCoding:
+		return c.GeoCoding, true
+	case ProviderWeatherAPISearch:
+		return c.WeatherAPISearch, true
+	default:
+		return ProviderConfig{}, false
+	}
 }
 
 func LoadConfig() (Config, error) {
@@ -63,14 +223,100 @@ func LoadConfig() (Config, error) {
 		log.Printf("warning: %v", err)
 	}
 
+	setDefaults(viper.GetViper())
+
 	var config Config
 	if err := readConfig(&config); err != nil {
 		return Config{}, fmt.Errorf("failed to read config: %w", err)
 	}
+	applyLegacyProviderSettings(&config)
+	if err := config.Admin.parseCredentials(); err != nil {
+		return Config{}, err
+	}
 
 	return config, nil
 }
 
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("redis.mode", RedisStandalone)
+	v.SetDefault("providers.weather_chain", []string{ProviderOpenMeteo, ProviderWeatherAPI})
+	v.SetDefault("providers.validation_chain", []string{ProviderGeoCoding, ProviderWeatherAPISearch})
+	v.SetDefault("providers.search_chain", []string{ProviderGeoCoding})
+	v.SetDefault("providers.no_fallback_on", []string{"not_found", "invalid_input"})
+	v.SetDefault("providers.hedge.delay", 300*time.Millisecond)
+	v.SetDefault("providers.hedge.quantile", 0.95)
+	v.SetDefault("providers.hedge.window", 100)
+	v.SetDefault("providers.hedge.min_delay", 50*time.Millisecond)
+	v.SetDefault("providers.location.cache_prefix", "location")
+	v.SetDefault("providers.location.cache_ttl", 30*24*time.Hour)
+	v.SetDefault("cache.local.size", 10000)
+	v.SetDefault("cache.local.ttl", time.Minute)
+	v.SetDefault("cache.fallback.size", 10000)
+	v.SetDefault("cache.fallback.ttl", 10*time.Minute)
+	v.SetDefault("cache.health_check_interval", time.Second)
+	v.SetDefault("cache.codec", "json")
+	v.SetDefault("cache.lock_ttl", 5*time.Second)
+	v.SetDefault("cache.negative_ttl", 5*time.Minute)
+	v.SetDefault("cache.stale_while_revalidate", 5*time.Minute)
+	v.SetDefault("cache.stale_if_error", 3*time.Hour)
+	v.SetDefault("jobs.warmup_lead", 10*time.Minute)
+	v.SetDefault("jobs.warmup_concurrency", 5)
+	v.SetDefault("manage.link_ttl", 30*time.Minute)
+
+	defaults := map[string]struct {
+		prefix      string
+		ttl         time.Duration
+		quotaGroup  string
+		hourlyQuota int
+		dailyQuota  int
+	}{
+		"open_meteo": {
+			prefix: "open-meteo", ttl: time.Hour,
+			quotaGroup: ProviderOpenMeteo, hourlyQuota: 5000, dailyQuota: 10000,
+		},
+		"weather_api": {
+			prefix: "weather-api", ttl: 15 * time.Minute,
+			quotaGroup: ProviderWeatherAPI, hourlyQuota: 1000,
+		},
+		"geo_coding": {
+			prefix: "geo-coding", ttl: 24 * time.Hour,
+			quotaGroup: ProviderGeoCoding,
+		},
+		"weather_api_search": {
+			prefix: "weather-search", ttl: 24 * time.Hour,
+			quotaGroup: ProviderWeatherAPI, hourlyQuota: 1000,
+		},
+	}
+	for key, d := range defaults {
+		v.SetDefault("providers."+key+".enabled", true)
+		v.SetDefault("providers."+key+".timeout", 10*time.Second)
+		v.SetDefault("providers."+key+".retry_attempts", 3)
+		v.SetDefault("providers."+key+".cache_prefix", d.prefix)
+		v.SetDefault("providers."+key+".cache_ttl", d.ttl)
+		v.SetDefault("providers."+key+".quota_group", d.quotaGroup)
+		v.SetDefault("providers."+key+".hourly_quota", d.hourlyQuota)
+		v.SetDefault("providers."+key+".daily_quota", d.dailyQuota)
+	}
+}
+
+func applyLegacyProviderSettings(config *Config) {
+	providers := &config.Providers
+	if providers.OpenMeteo.URL == "" {
+		providers.OpenMeteo.URL = config.OpenMeteoURL
+	}
+	if providers.GeoCoding.URL == "" {
+		providers.GeoCoding.URL = config.GeoCodingURL
+	}
+	for _, provider := range []*ProviderConfig{&providers.WeatherAPI, &providers.WeatherAPISearch} {
+		if provider.URL == "" {
+			provider.URL = config.Weather.ApiURL
+		}
+		if provider.APIKey == "" {
+			provider.APIKey = config.Weather.ApiKey
+		}
+	}
+}
+
 func loadEnvFile(path string) error {
 	if err := godotenv.Load(path); err != nil {
 		return fmt.Errorf("error loading .env file from %s: %w", path, err)
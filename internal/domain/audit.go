@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// AuditEntry records an administrative action and how it ended.
+type AuditEntry struct {
+	Actor      string
+	RemoteAddr string
+	Action     string
+	Target     string
+	Outcome    string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,9 @@
+package domain
+
+type Location struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+	Country   string
+	Timezone  string
+}
@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+const (
+	maxScheduleLength = 100
+	minutesPerDay     = 24 * 60
+)
+
+var (
+	dayListPattern = regexp.MustCompile(
+		`^(daily|weekdays|weekends|weekly on ([a-z, ]+?))(?: at (\d{2}:\d{2}))?$`)
+	intervalPattern = regexp.MustCompile(
+		`^every (\d{1,2}) hours?(?: between (\d{2}:\d{2}) and (\d{2}:\d{2}))?$`)
+)
+
+// Schedule is a parsed custom schedule expression. Supported forms are
+//
+//	daily | weekdays | weekends | weekly on <day>[, <day>...]  [at HH:MM]
+//	every N hours [between HH:MM and HH:MM]
+//
+// Times are wall-clock times in the subscription's timezone. Day-based
+// schedules without "at" use the subscription's delivery time.
+type Schedule struct {
+	expression string
+	days       [7]bool
+	// minutes after local midnight; empty means the delivery time
+	times    []int
+	interval bool
+}
+
+func ParseSchedule(expression string) (*Schedule, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(expression)), " ")
+	if normalized == "" || len(normalized) > maxScheduleLength {
+		return nil, invalidSchedule("schedule must be 1 to 100 characters")
+	}
+
+	if match := dayListPattern.FindStringSubmatch(normalized); match != nil {
+		return parseDayList(normalized, match)
+	}
+	if match := intervalPattern.FindStringSubmatch(normalized); match != nil {
+		return parseInterval(normalized, match)
+	}
+	return nil, invalidSchedule("unrecognized schedule")
+}
+
+func (s *Schedule) String() string {
+	return s.expression
+}
+
+// Repeating reports whether the schedule fires several times a day.
+func (s *Schedule) Repeating() bool {
+	return s.interval
+}
+
+// DueBetween reports whether the schedule fires in (from, to], evaluated in
+// loc. Occurrences are computed on the local dates of both bounds, so a
+// window spanning local midnight is covered.
+func (s *Schedule) DueBetween(from, to time.Time, loc *time.Location, deliveryTime string) bool {
+	times, ok := s.clockTimes(deliveryTime)
+	if !ok {
+		return false
+	}
+
+	for _, bound := range []time.Time{from.In(loc), to.In(loc)} {
+		if !s.days[bound.Weekday()] {
+			continue
+		}
+		year, month, day := bound.Date()
+		for _, minutes := range times {
+			instant := time.Date(year, month, day, minutes/60, minutes%60, 0, 0, loc)
+			if instant.After(from) && !instant.After(to) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Next returns the first time after the given one the schedule fires,
+// evaluated in loc, or the zero time if it never does.
+func (s *Schedule) Next(after time.Time, loc *time.Location, deliveryTime string) time.Time {
+	times, ok := s.clockTimes(deliveryTime)
+	if !ok {
+		return time.Time{}
+	}
+
+	year, month, day := after.In(loc).Date()
+	for offset := 0; offset <= len(s.days); offset++ {
+		date := time.Date(year, month, day+offset, 0, 0, 0, 0, loc)
+		if !s.days[date.Weekday()] {
+			continue
+		}
+		for _, minutes := range times {
+			instant := time.Date(year, month, day+offset, minutes/60, minutes%60, 0, 0, loc)
+			if instant.After(after) {
+				return instant
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// clockTimes returns the schedule's times of day in minutes, in ascending
+// order, using the delivery time when the schedule names none.
+func (s *Schedule) clockTimes(deliveryTime string) ([]int, bool) {
+	if len(s.times) > 0 {
+		return s.times, true
+	}
+	at, err := parseClock(deliveryTime)
+	if err != nil {
+		return nil, false
+	}
+	return []int{at}, true
+}
+
+func parseDayList(expression string, match []string) (*Schedule, error) {
+	schedule := &Schedule{expression: expression}
+
+	switch match[1] {
+	case "daily":
+		schedule.days = everyDay()
+	case "weekdays":
+		for day := time.Monday; day <= time.Friday; day++ {
+			schedule.days[day] = true
+		}
+	case "weekends":
+		schedule.days[time.Saturday] = true
+		schedule.days[time.Sunday] = true
+	default:
+		days, err := parseDays(match[2])
+		if err != nil {
+			return nil, err
+		}
+		schedule.days = days
+	}
+
+	if match[3] != "" {
+		at, err := parseClock(match[3])
+		if err != nil {
+			return nil, invalidSchedule("time must be HH:MM")
+		}
+		schedule.times = []int{at}
+	}
+	return schedule, nil
+}
+
+func parseInterval(expression string, match []string) (*Schedule, error) {
+	hours, _ := strconv.Atoi(match[1])
+	if hours < 1 || hours > 23 {
+		return nil, invalidSchedule("interval must be between 1 and 23 hours")
+	}
+
+	start, end := 0, minutesPerDay-1
+	if match[2] != "" {
+		var err error
+		if start, err = parseClock(match[2]); err != nil {
+			return nil, invalidSchedule("time must be HH:MM")
+		}
+		if end, err = parseClock(match[3]); err != nil {
+			return nil, invalidSchedule("time must be HH:MM")
+		}
+		if end < start {
+			return nil, invalidSchedule("time window must not cross midnight")
+		}
+	}
+
+	schedule := &Schedule{expression: expression, days: everyDay(), interval: true}
+	for minutes := start; minutes <= end; minutes += hours * 60 {
+		schedule.times = append(schedule.times, minutes)
+	}
+	return schedule, nil
+}
+
+// parseDays accepts full or three-letter day names separated by commas or
+// "and".
+func parseDays(list string) ([7]bool, error) {
+	var days [7]bool
+	names := strings.FieldsFunc(strings.ReplaceAll(list, " and ", ","), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	if len(names) == 0 {
+		return days, invalidSchedule("weekly schedule needs at least one day")
+	}
+
+	for _, name := range names {
+		day, ok := weekday(name)
+		if !ok {
+			return days, invalidSchedule("unknown day " + name)
+		}
+		days[day] = true
+	}
+	return days, nil
+}
+
+func weekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+func everyDay() [7]bool {
+	return [7]bool{true, true, true, true, true, true, true}
+}
+
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse(deliveryTimeLayout, value)
+	if err != nil {
+		return 0, err
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+func invalidSchedule(reason string) error {
+	return pkgErrors.New(internalErrors.ErrInvalidInput, "invalid schedule: "+reason)
+}
@@ -0,0 +1,174 @@
+//go:build unit
+// +build unit
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule_Valid(t *testing.T) {
+	tests := []struct {
+		expression string
+		normalized string
+		repeating  bool
+	}{
+		{expression: "weekdays at 07:00", normalized: "weekdays at 07:00"},
+		{expression: "  Weekly on  Sunday ", normalized: "weekly on sunday"},
+		{
+			expression: "weekly on Mon, Wed and Fri at 18:30",
+			normalized: "weekly on mon, wed and fri at 18:30",
+		},
+		{expression: "daily at 21:00", normalized: "daily at 21:00"},
+		{expression: "weekends", normalized: "weekends"},
+		{
+			expression: "every 3 hours between 06:00 and 22:00",
+			normalized: "every 3 hours between 06:00 and 22:00",
+			repeating:  true,
+		},
+		{expression: "every 1 hour", normalized: "every 1 hour", repeating: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expression)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.normalized, schedule.String())
+			assert.Equal(t, tt.repeating, schedule.Repeating())
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	expressions := []string{
+		"",
+		"sometimes",
+		"weekly on funday",
+		"weekdays at 25:00",
+		"every 0 hours",
+		"every 24 hours",
+		"every 2 hours between 22:00 and 06:00",
+		"hourly",
+	}
+
+	for _, expression := range expressions {
+		t.Run(expression, func(t *testing.T) {
+			_, err := ParseSchedule(expression)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSchedule_DueBetween(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	require.NoError(t, err)
+	// Thursday 2025-06-26 and Sunday 2025-06-29, Kyiv is UTC+3.
+	thursday := func(hour, minute int) time.Time {
+		return time.Date(2025, 6, 26, hour, minute, 0, 0, kyiv)
+	}
+	sunday := time.Date(2025, 6, 29, 8, 0, 0, 0, kyiv)
+
+	tests := []struct {
+		name       string
+		expression string
+		at         time.Time
+		want       bool
+	}{
+		{name: "weekday at time", expression: "weekdays at 07:00", at: thursday(7, 0), want: true},
+		{name: "weekday other minute", expression: "weekdays at 07:00", at: thursday(7, 1)},
+		{name: "weekend on weekday", expression: "weekends at 07:00", at: thursday(7, 0)},
+		{name: "weekly uses delivery time", expression: "weekly on sunday", at: sunday, want: true},
+		{name: "weekly other day", expression: "weekly on sunday", at: thursday(8, 0)},
+		{name: "interval start", expression: "every 3 hours between 06:00 and 22:00",
+			at: thursday(6, 0), want: true},
+		{name: "interval step", expression: "every 3 hours between 06:00 and 22:00",
+			at: thursday(21, 0), want: true},
+		{name: "interval off step", expression: "every 3 hours between 06:00 and 22:00",
+			at: thursday(22, 0)},
+		{name: "interval outside window", expression: "every 3 hours between 06:00 and 22:00",
+			at: thursday(3, 0)},
+		{name: "midnight", expression: "daily at 00:00", at: thursday(0, 0), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expression)
+			require.NoError(t, err)
+
+			due := schedule.DueBetween(tt.at.Add(-time.Minute), tt.at, kyiv, DefaultDeliveryTime)
+
+			assert.Equal(t, tt.want, due)
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	require.NoError(t, err)
+	// Thursday 2025-06-26 07:00 in Kyiv.
+	thursday := time.Date(2025, 6, 26, 7, 0, 0, 0, kyiv)
+
+	tests := []struct {
+		expression string
+		want       time.Time
+	}{
+		{expression: "weekdays at 07:00", want: time.Date(2025, 6, 27, 7, 0, 0, 0, kyiv)},
+		{expression: "weekdays at 07:30", want: time.Date(2025, 6, 26, 7, 30, 0, 0, kyiv)},
+		{expression: "weekly on sunday", want: time.Date(2025, 6, 29, 8, 0, 0, 0, kyiv)},
+		{expression: "weekly on thu", want: time.Date(2025, 6, 26, 8, 0, 0, 0, kyiv)},
+		{expression: "weekly on thu at 06:00", want: time.Date(2025, 7, 3, 6, 0, 0, 0, kyiv)},
+		{
+			expression: "every 3 hours between 06:00 and 22:00",
+			want:       time.Date(2025, 6, 26, 9, 0, 0, 0, kyiv),
+		},
+		{
+			expression: "every 6 hours between 06:00 and 07:00",
+			want:       time.Date(2025, 6, 27, 6, 0, 0, 0, kyiv),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expression)
+			require.NoError(t, err)
+
+			next := schedule.Next(thursday, kyiv, DefaultDeliveryTime)
+
+			assert.True(t, tt.want.Equal(next), "got %s", next)
+			assert.True(t, schedule.DueBetween(next.Add(-time.Minute), next, kyiv,
+				DefaultDeliveryTime))
+		})
+	}
+}
+
+func TestNewSubscription_Schedule(t *testing.T) {
+	custom, err := NewSubscription("user@example.com", "Kyiv", FrequencyCustom,
+		Delivery{Schedule: "Weekdays  at 07:00"})
+	require.NoError(t, err)
+	assert.Equal(t, "weekdays at 07:00", custom.Schedule)
+
+	_, err = NewSubscription("user@example.com", "Kyiv", FrequencyCustom, Delivery{})
+	assert.Error(t, err)
+
+	_, err = NewSubscription("user@example.com", "Kyiv", FrequencyDaily,
+		Delivery{Schedule: "weekdays at 07:00"})
+	assert.Error(t, err)
+}
+
+func TestSubscription_RescheduleClearsNextRun(t *testing.T) {
+	next := time.Date(2025, 6, 27, 4, 0, 0, 0, time.UTC)
+	subscription := &Subscription{Email: "user@example.com", City: "Kyiv",
+		Frequency: FrequencyCustom, Schedule: "weekdays at 07:00", DeliveryTime: "08:00",
+		Timezone: "Europe/Kyiv", NextRunAt: &next}
+
+	err := subscription.Reschedule("", Delivery{Schedule: "weekends at 09:00"})
+
+	require.NoError(t, err)
+	assert.Nil(t, subscription.NextRunAt)
+}
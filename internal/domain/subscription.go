@@ -2,6 +2,7 @@ package domain
 
 import (
 	"net/mail"
+	"strings"
 	"time"
 
 	internalErrors "weather-api/internal/errors"
@@ -15,17 +16,50 @@ type Frequency string
 const (
 	FrequencyHourly Frequency = "hourly"
 	FrequencyDaily  Frequency = "daily"
+	// FrequencyCustom subscriptions follow their Schedule expression.
+	FrequencyCustom Frequency = "custom"
+)
+
+const (
+	DefaultDeliveryTime = "08:00"
+	DefaultTimezone     = "UTC"
+	deliveryTimeLayout  = "15:04"
 )
 
 type Subscription struct {
-	ID        uint
+	ID           uint
+	Email        string
+	City         string
+	Frequency    Frequency
+	DeliveryTime string
+	Timezone     string
+	Schedule     string
+	Token        string
+	Confirmed    bool
+	Paused       bool
+	// NextRunAt is when a custom schedule fires next, as last computed by the
+	// job that sends it; nil until then and after every reschedule.
+	NextRunAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Delivery is when updates are sent: a wall-clock time (HH:MM) in an IANA
+// timezone, plus the schedule expression of custom subscriptions. Empty Time
+// and Timezone fall back to DefaultDeliveryTime and DefaultTimezone.
+type Delivery struct {
+	Time     string
+	Timezone string
+	Schedule string
+}
+
+// SubscriptionChanges lists what an update replaces. Empty fields keep their
+// current values.
+type SubscriptionChanges struct {
 	Email     string
 	City      string
 	Frequency Frequency
-	Token     string
-	Confirmed bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Delivery  Delivery
 }
 
 type SubscriptionLookup struct {
@@ -39,15 +73,20 @@ type GroupedSubscription struct {
 	Subscriptions []*Subscription
 }
 
-func NewSubscription(email, city string, frequency Frequency) (*Subscription, error) {
+func NewSubscription(
+	email, city string, frequency Frequency, delivery Delivery,
+) (*Subscription, error) {
 	sub := &Subscription{
-		Email:     email,
-		City:      city,
-		Frequency: frequency,
-		Token:     uuid.New().String(),
-		Confirmed: false,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Email:        email,
+		City:         city,
+		Frequency:    frequency,
+		DeliveryTime: valueOr(delivery.Time, DefaultDeliveryTime),
+		Timezone:     valueOr(delivery.Timezone, DefaultTimezone),
+		Schedule:     delivery.Schedule,
+		Token:        uuid.New().String(),
+		Confirmed:    false,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 
 	if err := sub.validate(); err != nil {
@@ -62,6 +101,96 @@ func (s *Subscription) SetConfirmed(confirmed bool) {
 	s.UpdatedAt = time.Now()
 }
 
+func (s *Subscription) SetPaused(paused bool) {
+	s.Paused = paused
+	s.UpdatedAt = time.Now()
+}
+
+// Reschedule changes how often and when the subscription is delivered. Empty
+// values keep the current ones, except that leaving the custom frequency
+// drops the schedule. The subscription is left untouched on error.
+func (s *Subscription) Reschedule(frequency Frequency, delivery Delivery) error {
+	updated := *s
+	updated.Frequency = Frequency(valueOr(string(frequency), string(s.Frequency)))
+	updated.DeliveryTime = valueOr(delivery.Time, s.DeliveryTime)
+	updated.Timezone = valueOr(delivery.Timezone, s.Timezone)
+
+	schedule := ""
+	if updated.Frequency == FrequencyCustom {
+		schedule = s.Schedule
+	}
+	updated.Schedule = valueOr(delivery.Schedule, schedule)
+
+	if err := updated.validate(); err != nil {
+		return err
+	}
+	updated.NextRunAt = nil
+	updated.UpdatedAt = time.Now()
+	*s = updated
+	return nil
+}
+
+// Update applies the changes and reports whether the subscription must be
+// confirmed again. A new email address is unconfirmed until its owner follows
+// a link sent under a fresh token, so the old token cannot confirm it. The
+// subscription is left untouched on error.
+func (s *Subscription) Update(changes SubscriptionChanges) (bool, error) {
+	updated := *s
+	updated.City = valueOr(changes.City, s.City)
+	updated.Email = valueOr(changes.Email, s.Email)
+
+	reconfirm := !strings.EqualFold(updated.Email, s.Email)
+	if reconfirm {
+		updated.Token = uuid.New().String()
+		updated.Confirmed = false
+	}
+
+	if err := updated.Reschedule(changes.Frequency, changes.Delivery); err != nil {
+		return false, err
+	}
+	*s = updated
+	return reconfirm, nil
+}
+
+func (s *Subscription) Lookup() *SubscriptionLookup {
+	return &SubscriptionLookup{Email: s.Email, City: s.City, Frequency: s.Frequency}
+}
+
+// DueBetween reports whether a custom subscription's schedule fires in
+// (from, to], in the subscription's timezone.
+func (s *Subscription) DueBetween(from, to time.Time) bool {
+	if s.Frequency != FrequencyCustom {
+		return false
+	}
+	schedule, err := ParseSchedule(s.Schedule)
+	if err != nil {
+		return false
+	}
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false
+	}
+	return schedule.DueBetween(from, to, location, s.DeliveryTime)
+}
+
+// NextRun returns when a custom subscription's schedule fires first after the
+// given time, in the subscription's timezone, or false if it never does.
+func (s *Subscription) NextRun(after time.Time) (time.Time, bool) {
+	if s.Frequency != FrequencyCustom {
+		return time.Time{}, false
+	}
+	schedule, err := ParseSchedule(s.Schedule)
+	if err != nil {
+		return time.Time{}, false
+	}
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	next := schedule.Next(after, location, s.DeliveryTime)
+	return next, !next.IsZero()
+}
+
 func (s *Subscription) validate() error {
 	if _, err := mail.ParseAddress(s.Email); err != nil {
 		return pkgErrors.New(internalErrors.ErrInvalidInput, "invalid email address")
@@ -75,9 +204,53 @@ func (s *Subscription) validate() error {
 		return pkgErrors.New(internalErrors.ErrInvalidInput, "invalid frequency value")
 	}
 
+	if _, err := time.Parse(deliveryTimeLayout, s.DeliveryTime); err != nil {
+		return pkgErrors.New(internalErrors.ErrInvalidInput, "delivery time must be HH:MM")
+	}
+
+	if !isValidTimezone(s.Timezone) {
+		return pkgErrors.New(internalErrors.ErrInvalidInput, "invalid timezone")
+	}
+
+	return s.validateSchedule()
+}
+
+// validateSchedule stores custom schedules in their normalized form; other
+// frequencies must not carry one.
+func (s *Subscription) validateSchedule() error {
+	if s.Frequency != FrequencyCustom {
+		if s.Schedule != "" {
+			return pkgErrors.New(
+				internalErrors.ErrInvalidInput, "schedule requires the custom frequency",
+			)
+		}
+		return nil
+	}
+
+	schedule, err := ParseSchedule(s.Schedule)
+	if err != nil {
+		return err
+	}
+	s.Schedule = schedule.String()
 	return nil
 }
 
+// isValidTimezone accepts IANA names only; "Local" would depend on the server.
+func isValidTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
 func isValidFrequency(f Frequency) bool {
-	return f == FrequencyHourly || f == FrequencyDaily
+	return f == FrequencyHourly || f == FrequencyDaily || f == FrequencyCustom
 }
@@ -1,9 +1,39 @@
 package domain
 
+import (
+	"fmt"
+	"math"
+	"time"
+
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+// Freshness records when weather data was fetched from its provider. Data
+// served from a cache keeps the time of the original fetch.
+//
+// Timezone and ValidUntil are what the provider reported about the data: the
+// IANA timezone of the location and the time it expects to have newer data.
+// Either may be empty when the provider did not say.
+type Freshness struct {
+	FetchedAt  time.Time
+	Timezone   string
+	ValidUntil time.Time
+}
+
+func (f *Freshness) MarkFetched(at time.Time) {
+	f.FetchedAt = at
+}
+
+func (f *Freshness) Hints() Freshness {
+	return *f
+}
+
 type Weather struct {
 	Temperature float64
 	Humidity    float64
 	Description string
+	Freshness
 }
 
 type WeatherDaily struct {
@@ -18,6 +48,7 @@ type WeatherDaily struct {
 	ChanceSnow int
 	Condition  string
 	Icon       string
+	Freshness
 }
 
 type WeatherHourly struct {
@@ -30,4 +61,88 @@ type WeatherHourly struct {
 	ChanceSnow int
 	Condition  string
 	Icon       string
+	Freshness
+}
+
+const (
+	DefaultTimelineHours = 24
+	MaxTimelineHours     = 48
+)
+
+type WeatherTimeline struct {
+	Location string
+	Hours    []WeatherHourly
+	Freshness
+}
+
+const (
+	minPlausibleTempC = -90.0
+	maxPlausibleTempC = 57.0
+)
+
+func (w *Weather) Validate() error {
+	if err := validateTemperature(w.Temperature); err != nil {
+		return err
+	}
+	if w.Humidity < 0 || w.Humidity > 100 {
+		return invalidData("humidity out of range")
+	}
+	return nil
+}
+
+func (w *WeatherDaily) Validate() error {
+	if w.Date == "" {
+		return invalidData("daily forecast without date")
+	}
+	for _, temp := range []float64{w.MinTempC, w.MaxTempC, w.AvgTempC} {
+		if err := validateTemperature(temp); err != nil {
+			return err
+		}
+	}
+	if w.MinTempC > w.MaxTempC {
+		return invalidData("minimum temperature above maximum")
+	}
+	return validateChances(w.ChanceRain, w.ChanceSnow)
+}
+
+func (w *WeatherHourly) Validate() error {
+	if w.Time == "" {
+		return invalidData("hourly forecast without time")
+	}
+	if err := validateTemperature(w.TempC); err != nil {
+		return err
+	}
+	return validateChances(w.ChanceRain, w.ChanceSnow)
+}
+
+func (w *WeatherTimeline) Validate() error {
+	if len(w.Hours) == 0 {
+		return invalidData("empty hourly timeline")
+	}
+	for i := range w.Hours {
+		if err := w.Hours[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTemperature(tempC float64) error {
+	if math.IsNaN(tempC) || tempC < minPlausibleTempC || tempC > maxPlausibleTempC {
+		return invalidData(fmt.Sprintf("implausible temperature %.1f°C", tempC))
+	}
+	return nil
+}
+
+func validateChances(chances ...int) error {
+	for _, chance := range chances {
+		if chance < 0 || chance > 100 {
+			return invalidData("precipitation chance out of range")
+		}
+	}
+	return nil
+}
+
+func invalidData(message string) error {
+	return pkgErrors.New(internalErrors.ErrInvalidData, message)
 }
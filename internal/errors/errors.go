@@ -1,6 +1,9 @@
 package errors
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 var (
 	ErrNotFound           = errors.New("not found")
@@ -8,4 +11,48 @@ var (
 	ErrConflict           = errors.New("conflict")
 	ErrInternal           = errors.New("internal error")
 	ErrServiceUnavailable = errors.New("service unavailable")
+	ErrQuotaExceeded      = errors.New("quota exceeded")
+	ErrInvalidData        = errors.New("invalid provider data")
+	ErrUnauthorized       = errors.New("unauthorized")
+)
+
+const (
+	ClassOK            = "ok"
+	ClassCanceled      = "canceled"
+	ClassNotFound      = "not_found"
+	ClassInvalidInput  = "invalid_input"
+	ClassConflict      = "conflict"
+	ClassInternal      = "internal"
+	ClassUnavailable   = "unavailable"
+	ClassQuotaExceeded = "quota_exceeded"
+	ClassInvalidData   = "invalid_data"
+	ClassUnknown       = "unknown"
 )
+
+var classes = []struct {
+	err  error
+	name string
+}{
+	{err: ErrNotFound, name: ClassNotFound},
+	{err: ErrInvalidInput, name: ClassInvalidInput},
+	{err: ErrConflict, name: ClassConflict},
+	{err: ErrQuotaExceeded, name: ClassQuotaExceeded},
+	{err: ErrInvalidData, name: ClassInvalidData},
+	{err: ErrServiceUnavailable, name: ClassUnavailable},
+	{err: ErrInternal, name: ClassInternal},
+}
+
+func Class(err error) string {
+	if err == nil {
+		return ClassOK
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ClassCanceled
+	}
+	for _, class := range classes {
+		if errors.Is(err, class.err) {
+			return class.name
+		}
+	}
+	return ClassUnknown
+}
@@ -0,0 +1,184 @@
+package chain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	internalErrors "weather-api/internal/errors"
+)
+
+const ReasonHedge = "hedge"
+
+type FallbackRecorder interface {
+	Fallback(chain, reason string)
+}
+
+type Link[C any] struct {
+	Name   string
+	Client C
+}
+
+type Chain[C any] struct {
+	name     string
+	links    []Link[C]
+	policy   Policy
+	hedge    HedgePolicy
+	recorder FallbackRecorder
+}
+
+func New[C any](name string, links []Link[C], policy Policy) *Chain[C] {
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+	return &Chain[C]{name: name, links: links, policy: policy}
+}
+
+func (c *Chain[C]) SetHedging(policy HedgePolicy) {
+	c.hedge = policy
+}
+
+func (c *Chain[C]) SetFallbackRecorder(recorder FallbackRecorder) {
+	c.recorder = recorder
+}
+
+func (c *Chain[C]) fallback(reason string) {
+	if c.recorder != nil {
+		c.recorder.Fallback(c.name, reason)
+	}
+}
+
+type Call[C, T any] func(ctx context.Context, client C) (T, error)
+
+type result[T any] struct {
+	value T
+	err   error
+	index int
+}
+
+type run[C, T any] struct {
+	ctx      context.Context
+	chain    *Chain[C]
+	call     Call[C, T]
+	results  chan result[T]
+	start    time.Time
+	next     int
+	pending  int
+	attempts []Attempt
+	timer    *time.Timer
+	observed bool
+}
+
+// Execute tries the links in order until one succeeds or the policy decides
+// an error is final. With hedging enabled the next link is also started once
+// the hedge delay elapses; the first successful answer wins and the other
+// calls are cancelled.
+func Execute[C, T any](ctx context.Context, c *Chain[C], call Call[C, T]) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r := &run[C, T]{
+		ctx:     ctx,
+		chain:   c,
+		call:    call,
+		results: make(chan result[T], len(c.links)),
+		start:   time.Now(),
+	}
+	defer r.stopTimer()
+
+	var zero T
+	if !r.launch("") {
+		return zero, &Error{Chain: c.name}
+	}
+
+	for {
+		select {
+		case <-r.timerC():
+			r.launch(ReasonHedge)
+		case res := <-r.results:
+			r.pending--
+			r.observe(res)
+			if res.err == nil {
+				return res.value, nil
+			}
+			if !r.handleFailure(res) {
+				return zero, r.error()
+			}
+		}
+	}
+}
+
+// handleFailure records a failed attempt and reports whether the run should
+// keep waiting for other links.
+func (r *run[C, T]) handleFailure(res result[T]) bool {
+	r.attempts = append(r.attempts, Attempt{Link: r.chain.links[res.index].Name, Err: res.err})
+	if !r.chain.policy.ShouldFallback(res.err) {
+		return false
+	}
+	if res.index == r.next-1 || r.pending == 0 {
+		r.launch(internalErrors.Class(res.err))
+	}
+	return r.pending > 0
+}
+
+func (r *run[C, T]) launch(reason string) bool {
+	if r.next >= len(r.chain.links) {
+		return false
+	}
+	if r.next > 0 {
+		r.chain.fallback(reason)
+	}
+
+	index := r.next
+	client := r.chain.links[index].Client
+	r.next++
+	r.pending++
+	go func() {
+		value, err := r.call(r.ctx, client)
+		r.results <- result[T]{value: value, err: err, index: index}
+	}()
+
+	r.stopTimer()
+	if r.chain.hedge != nil && r.next < len(r.chain.links) {
+		r.timer = time.NewTimer(r.chain.hedge.Delay())
+	}
+	return true
+}
+
+func (r *run[C, T]) timerC() <-chan time.Time {
+	if r.timer == nil {
+		return nil
+	}
+	return r.timer.C
+}
+
+func (r *run[C, T]) stopTimer() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+func (r *run[C, T]) observe(res result[T]) {
+	if r.chain.hedge == nil || r.observed {
+		return
+	}
+	if res.index == 0 || res.err == nil {
+		r.chain.hedge.Observe(time.Since(r.start))
+		r.observed = true
+	}
+}
+
+func (r *run[C, T]) error() error {
+	attempts := slices.Clone(r.attempts)
+	slices.SortFunc(attempts, func(a, b Attempt) int {
+		return r.linkIndex(a.Link) - r.linkIndex(b.Link)
+	})
+	return &Error{Chain: r.chain.name, Attempts: attempts}
+}
+
+func (r *run[C, T]) linkIndex(name string) int {
+	return slices.IndexFunc(r.chain.links, func(link Link[C]) bool {
+		return link.Name == name
+	})
+}
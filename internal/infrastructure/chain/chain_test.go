@@ -0,0 +1,225 @@
+//go:build unit
+// +build unit
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/infrastructure"
+	appHttp "weather-api/internal/infrastructure/http"
+	openMeteo "weather-api/internal/infrastructure/http/weather/providers/open-meteo"
+	weatherAPI "weather-api/internal/infrastructure/http/weather/providers/weather-api"
+	"weather-api/internal/test/stubs"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+type fakeClient struct {
+	delay     time.Duration
+	value     string
+	err       error
+	calls     atomic.Int32
+	cancelled atomic.Bool
+}
+
+func (c *fakeClient) Get(ctx context.Context) (string, error) {
+	c.calls.Add(1)
+	select {
+	case <-time.After(c.delay):
+		return c.value, c.err
+	case <-ctx.Done():
+		c.cancelled.Store(true)
+		return "", ctx.Err()
+	}
+}
+
+type fallbackRecorder struct {
+	reasons []string
+}
+
+func (r *fallbackRecorder) Fallback(_, reason string) {
+	r.reasons = append(r.reasons, reason)
+}
+
+func get(ctx context.Context, client *fakeClient) (string, error) {
+	return client.Get(ctx)
+}
+
+func newChain(clients ...*fakeClient) *Chain[*fakeClient] {
+	links := make([]Link[*fakeClient], 0, len(clients))
+	for i, client := range clients {
+		links = append(links, Link[*fakeClient]{Name: string(rune('a' + i)), Client: client})
+	}
+	return New("test", links, nil)
+}
+
+func TestExecute_FallsBackOnUnavailable(t *testing.T) {
+	primary := &fakeClient{err: pkgErrors.New(internalErrors.ErrServiceUnavailable, "down")}
+	secondary := &fakeClient{value: "secondary"}
+	recorder := &fallbackRecorder{}
+	clients := newChain(primary, secondary)
+	clients.SetFallbackRecorder(recorder)
+
+	value, err := Execute(context.Background(), clients, get)
+
+	require.NoError(t, err)
+	assert.Equal(t, "secondary", value)
+	assert.Equal(t, []string{internalErrors.ClassUnavailable}, recorder.reasons)
+}
+
+func TestExecute_NotFoundIsFinal(t *testing.T) {
+	notFound := pkgErrors.New(internalErrors.ErrNotFound, "city not found")
+	primary := &fakeClient{err: notFound}
+	secondary := &fakeClient{value: "secondary"}
+
+	_, err := Execute(context.Background(), newChain(primary, secondary), get)
+
+	assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+	assert.Equal(t, int32(0), secondary.calls.Load())
+}
+
+func TestExecute_CombinesErrorsInChainOrder(t *testing.T) {
+	first := pkgErrors.New(internalErrors.ErrServiceUnavailable, "first down")
+	second := pkgErrors.New(internalErrors.ErrQuotaExceeded, "second exhausted")
+	clients := newChain(&fakeClient{err: first}, &fakeClient{err: second})
+
+	_, err := Execute(context.Background(), clients, get)
+
+	var chainErr *Error
+	require.True(t, errors.As(err, &chainErr))
+	require.Len(t, chainErr.Attempts, 2)
+	assert.Equal(t, "a", chainErr.Attempts[0].Link)
+	assert.ErrorIs(t, err, internalErrors.ErrServiceUnavailable)
+	assert.ErrorIs(t, err, internalErrors.ErrQuotaExceeded)
+
+	apiErr, ok := pkgErrors.IsApiError(err)
+	require.True(t, ok)
+	assert.Equal(t, first, apiErr)
+}
+
+func TestExecute_FallbackOnPolicy(t *testing.T) {
+	primary := &fakeClient{err: pkgErrors.New(internalErrors.ErrInternal, "bad payload")}
+	secondary := &fakeClient{value: "secondary"}
+	links := []Link[*fakeClient]{{Name: "a", Client: primary}, {Name: "b", Client: secondary}}
+	clients := New("test", links, FallbackOn(internalErrors.ClassUnavailable))
+
+	_, err := Execute(context.Background(), clients, get)
+
+	assert.ErrorIs(t, err, internalErrors.ErrInternal)
+	assert.Equal(t, int32(0), secondary.calls.Load())
+}
+
+func TestExecute_HedgesSlowPrimary(t *testing.T) {
+	primary := &fakeClient{delay: time.Second, value: "primary"}
+	secondary := &fakeClient{value: "secondary"}
+	recorder := &fallbackRecorder{}
+	clients := newChain(primary, secondary)
+	clients.SetHedging(NewFixedHedge(10 * time.Millisecond))
+	clients.SetFallbackRecorder(recorder)
+
+	start := time.Now()
+	value, err := Execute(context.Background(), clients, get)
+
+	require.NoError(t, err)
+	assert.Equal(t, "secondary", value)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, []string{ReasonHedge}, recorder.reasons)
+	assert.Eventually(t, primary.cancelled.Load, time.Second, 5*time.Millisecond)
+}
+
+func TestExecute_DoesNotHedgeFastPrimary(t *testing.T) {
+	primary := &fakeClient{value: "primary"}
+	secondary := &fakeClient{value: "secondary"}
+	clients := newChain(primary, secondary)
+	clients.SetHedging(NewFixedHedge(100 * time.Millisecond))
+
+	value, err := Execute(context.Background(), clients, get)
+
+	require.NoError(t, err)
+	assert.Equal(t, "primary", value)
+	assert.Equal(t, int32(0), secondary.calls.Load())
+}
+
+func TestExecute_HedgedReturnsErrorWhenAllFail(t *testing.T) {
+	primary := &fakeClient{err: pkgErrors.New(internalErrors.ErrServiceUnavailable, "down")}
+	secondary := &fakeClient{
+		delay: 10 * time.Millisecond,
+		err:   pkgErrors.New(internalErrors.ErrServiceUnavailable, "secondary down"),
+	}
+	clients := newChain(primary, secondary)
+	clients.SetHedging(NewFixedHedge(time.Second))
+
+	_, err := Execute(context.Background(), clients, get)
+
+	var chainErr *Error
+	require.True(t, errors.As(err, &chainErr))
+	assert.Len(t, chainErr.Attempts, 2)
+	assert.Equal(t, int32(1), secondary.calls.Load())
+}
+
+type currentWeatherClient interface {
+	GetWeather(ctx context.Context, city string) (*domain.Weather, error)
+}
+
+func TestExecute_DoesNotFallBackWhenProviderRequestIsCancelled(t *testing.T) {
+	arrived := make(chan struct{}, 1)
+	primaryServer := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-r.Context().Done()
+	}))
+	t.Cleanup(primaryServer.Close)
+	var secondaryCalls atomic.Int32
+	secondaryServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			secondaryCalls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	))
+	t.Cleanup(secondaryServer.Close)
+
+	retry := appHttp.NewRetryPolicy("test", 3, time.Millisecond, time.Millisecond, nil)
+	clock := infrastructure.SystemClock{}
+	clients := New("current", []Link[currentWeatherClient]{
+		{Name: "open-meteo", Client: openMeteo.NewClient(primaryServer.URL,
+			stubs.NewLocationResolverStub(), appHttp.NoOpLogger{}, clock, time.Minute, retry)},
+		{Name: "weather-api", Client: weatherAPI.NewClient(secondaryServer.URL, "key",
+			appHttp.NoOpLogger{}, clock, time.Minute, retry)},
+	}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+
+	_, err := Execute(ctx, clients, func(
+		ctx context.Context, client currentWeatherClient,
+	) (*domain.Weather, error) {
+		return client.GetWeather(ctx, "Kyiv")
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, internalErrors.ClassCanceled, internalErrors.Class(err))
+	assert.Zero(t, secondaryCalls.Load())
+}
+
+func TestLatencyHedge_UsesQuantileOnceWindowFilled(t *testing.T) {
+	hedge := NewLatencyHedge(4, 0.75, 300*time.Millisecond, 10*time.Millisecond)
+	assert.Equal(t, 300*time.Millisecond, hedge.Delay())
+
+	for _, latency := range []time.Duration{40, 10, 30, 20} {
+		hedge.Observe(latency * time.Millisecond)
+	}
+
+	assert.Equal(t, 30*time.Millisecond, hedge.Delay())
+}
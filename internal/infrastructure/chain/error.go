@@ -0,0 +1,35 @@
+package chain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Attempt struct {
+	Link string
+	Err  error
+}
+
+// Error collects the failures of every link tried while handling a request.
+// Attempts are kept in chain order, so errors.As resolves to the error of the
+// earliest link that failed.
+type Error struct {
+	Chain    string
+	Attempts []Attempt
+}
+
+func (e *Error) Error() string {
+	causes := make([]string, 0, len(e.Attempts))
+	for _, attempt := range e.Attempts {
+		causes = append(causes, fmt.Sprintf("%s: %v", attempt.Link, attempt.Err))
+	}
+	return fmt.Sprintf("%s chain failed: %s", e.Chain, strings.Join(causes, "; "))
+}
+
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, len(e.Attempts))
+	for _, attempt := range e.Attempts {
+		errs = append(errs, attempt.Err)
+	}
+	return errs
+}
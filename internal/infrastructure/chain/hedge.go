@@ -0,0 +1,77 @@
+package chain
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+type HedgePolicy interface {
+	Delay() time.Duration
+	Observe(latency time.Duration)
+}
+
+type FixedHedge struct {
+	delay time.Duration
+}
+
+func NewFixedHedge(delay time.Duration) *FixedHedge {
+	return &FixedHedge{delay: delay}
+}
+
+func (h *FixedHedge) Delay() time.Duration {
+	return h.delay
+}
+
+func (h *FixedHedge) Observe(time.Duration) {}
+
+// LatencyHedge derives the hedge delay from a quantile of the most recent
+// primary latencies, falling back to the initial delay until the window fills.
+type LatencyHedge struct {
+	mu       sync.Mutex
+	samples  []time.Duration
+	next     int
+	filled   bool
+	quantile float64
+	initial  time.Duration
+	minDelay time.Duration
+}
+
+func NewLatencyHedge(
+	window int, quantile float64, initial, minDelay time.Duration,
+) *LatencyHedge {
+	return &LatencyHedge{
+		samples:  make([]time.Duration, max(window, 1)),
+		quantile: quantile,
+		initial:  initial,
+		minDelay: minDelay,
+	}
+}
+
+func (h *LatencyHedge) Delay() time.Duration {
+	h.mu.Lock()
+	if !h.filled {
+		h.mu.Unlock()
+		return h.initial
+	}
+	sorted := slices.Clone(h.samples)
+	h.mu.Unlock()
+
+	slices.Sort(sorted)
+	index := int(math.Ceil(h.quantile*float64(len(sorted)))) - 1
+	index = min(max(index, 0), len(sorted)-1)
+
+	return max(sorted[index], h.minDelay)
+}
+
+func (h *LatencyHedge) Observe(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.samples[h.next] = latency
+	h.next = (h.next + 1) % len(h.samples)
+	if h.next == 0 {
+		h.filled = true
+	}
+}
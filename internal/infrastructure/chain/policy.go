@@ -0,0 +1,42 @@
+package chain
+
+import (
+	"slices"
+
+	internalErrors "weather-api/internal/errors"
+)
+
+type Policy interface {
+	ShouldFallback(err error) bool
+}
+
+type classPolicy struct {
+	classes []string
+	match   bool
+}
+
+// FallbackOn falls back to the next link only for errors of the given classes.
+func FallbackOn(classes ...string) Policy {
+	return classPolicy{classes: classes, match: true}
+}
+
+// FallbackExcept falls back to the next link for every error class except the
+// given ones.
+func FallbackExcept(classes ...string) Policy {
+	return classPolicy{classes: classes, match: false}
+}
+
+// DefaultPolicy treats not found and invalid input answers as final, since
+// asking another provider about an unknown city only doubles the upstream
+// calls, and never falls back once the caller has gone away.
+func DefaultPolicy() Policy {
+	return FallbackExcept(
+		internalErrors.ClassNotFound,
+		internalErrors.ClassInvalidInput,
+		internalErrors.ClassCanceled,
+	)
+}
+
+func (p classPolicy) ShouldFallback(err error) bool {
+	return slices.Contains(p.classes, internalErrors.Class(err)) == p.match
+}
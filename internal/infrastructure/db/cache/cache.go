@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	TierLocal = "local"
+	TierRedis = "redis"
+)
+
+// Results of a cached lookup, as reported by the caching proxies.
+const (
+	ResultHit         = "hit"
+	ResultMiss        = "miss"
+	ResultStale       = "stale"
+	ResultNegative    = "negative_hit"
+	ResultUndecodable = "undecodable"
+)
+
+const (
+	OperationGet = "get"
+	OperationSet = "set"
+)
+
+var ErrMiss = errors.New("cache: miss")
+
+// Cache stores raw values by key. Get returns ErrMiss for absent keys along
+// with the remaining TTL of present ones (zero when it is unknown).
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, time.Duration, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+type MetricsRecorder interface {
+	CacheHit()
+	CacheMiss()
+}
+
+// OperationRecorder observes calls to a remote cache backend: their latency,
+// the size of the value read or written, and whether they failed.
+type OperationRecorder interface {
+	ObserveOperation(operation string, latency time.Duration, size int, err error)
+}
+
+type Clock interface {
+	Now() time.Time
+}
+
+type noopRecorder struct{}
+
+func (noopRecorder) CacheHit()  {}
+func (noopRecorder) CacheMiss() {}
+
+func (noopRecorder) ObserveOperation(string, time.Duration, int, error) {}
+
+func recorderOrNoop(recorder MetricsRecorder) MetricsRecorder {
+	if recorder == nil {
+		return noopRecorder{}
+	}
+	return recorder
+}
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+const lockPollInterval = 50 * time.Millisecond
+
+type Locker interface {
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), ok bool, err error)
+}
+
+// Coalescer makes sure a missing entry is refreshed by one caller at a time:
+// callers in the same process share a single load, and with a locker other
+// instances wait for the lock holder to store the value instead of loading it
+// themselves.
+type Coalescer struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+	locker  Locker
+	lockTTL time.Duration
+}
+
+// flight is a load shared by every caller of the same key in this process.
+type flight struct {
+	done    chan struct{}
+	value   any
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// NewCoalescer returns a coalescer that takes cross-instance locks for lockTTL.
+// A nil locker or a zero lockTTL coalesces within the process only.
+func NewCoalescer(locker Locker, lockTTL time.Duration) *Coalescer {
+	return &Coalescer{flights: make(map[string]*flight), locker: locker, lockTTL: lockTTL}
+}
+
+// Load returns lookup's answer when another caller has stored one in the
+// meantime and calls load otherwise. A lookup that finds a cached error, such
+// as a rejected city, returns it with found set, and that error is final. The
+// shared load outlives the caller that started it while others still wait on
+// it, and is cancelled once the last waiting caller gives up.
+func Load[T any](
+	ctx context.Context,
+	c *Coalescer,
+	key string,
+	lookup func(ctx context.Context) (T, bool, error),
+	load func(ctx context.Context) (T, error),
+) (T, error) {
+	f := c.join(ctx, key, func(ctx context.Context) (any, error) {
+		return c.load(ctx, key,
+			func(ctx context.Context) (any, bool, error) { return lookup(ctx) },
+			func(ctx context.Context) (any, error) { return load(ctx) },
+		)
+	})
+
+	var zero T
+	select {
+	case <-ctx.Done():
+		c.leave(key, f)
+		return zero, ctx.Err()
+	case <-f.done:
+		if f.err != nil {
+			return zero, f.err
+		}
+		return f.value.(T), nil
+	}
+}
+
+// join adds the caller to the flight for key, starting one if none is running.
+func (c *Coalescer) join(
+	ctx context.Context, key string, load func(ctx context.Context) (any, error),
+) *flight {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if f, ok := c.flights[key]; ok {
+		f.waiters++
+		return f
+	}
+
+	loadCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	f := &flight{done: make(chan struct{}), waiters: 1, cancel: cancel}
+	c.flights[key] = f
+
+	go func() {
+		defer cancel()
+		f.value, f.err = load(loadCtx)
+		c.mu.Lock()
+		c.forget(key, f)
+		c.mu.Unlock()
+		close(f.done)
+	}()
+	return f
+}
+
+// leave drops a caller that gave up and cancels the flight once nobody waits
+// for it. Later callers start a new flight instead of joining a cancelled one.
+func (c *Coalescer) leave(key string, f *flight) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f.waiters--
+	if f.waiters == 0 {
+		c.forget(key, f)
+		f.cancel()
+	}
+}
+
+func (c *Coalescer) forget(key string, f *flight) {
+	if c.flights[key] == f {
+		delete(c.flights, key)
+	}
+}
+
+// load takes the cross-instance lock before loading. While another instance
+// holds it, load polls for the value that instance stores and for the lock
+// itself: the holder releases the lock whether or not its load succeeded, and
+// the lock expires after lockTTL if the holder is gone, so a waiter takes over
+// instead of waiting out a failed load.
+func (c *Coalescer) load(
+	ctx context.Context,
+	key string,
+	lookup func(ctx context.Context) (any, bool, error),
+	load func(ctx context.Context) (any, error),
+) (any, error) {
+	if c.locker == nil || c.lockTTL <= 0 {
+		return load(ctx)
+	}
+
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		unlock, ok, err := c.locker.TryLock(ctx, key, c.lockTTL)
+		if err != nil {
+			log.Printf("cache: failed to lock key %s: %v\n", key, err)
+			return load(ctx)
+		}
+		if ok {
+			return loadLocked(ctx, unlock, lookup, load)
+		}
+
+		if value, found, err := await(ctx, ticker, lookup); found || err != nil {
+			return value, err
+		}
+	}
+}
+
+// loadLocked rechecks the cache under the lock, since the previous holder may
+// have stored the value just before releasing it.
+func loadLocked(
+	ctx context.Context,
+	unlock func(),
+	lookup func(ctx context.Context) (any, bool, error),
+	load func(ctx context.Context) (any, error),
+) (any, error) {
+	defer unlock()
+	if value, found, err := lookup(ctx); found {
+		return value, err
+	}
+	return load(ctx)
+}
+
+// await waits for the next poll and reports whether the lock holder has
+// stored an answer in the meantime.
+func await(
+	ctx context.Context, ticker *time.Ticker, lookup func(ctx context.Context) (any, bool, error),
+) (any, bool, error) {
+	select {
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	case <-ticker.C:
+		return lookup(ctx)
+	}
+}
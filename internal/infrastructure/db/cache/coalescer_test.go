@@ -0,0 +1,205 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockerStub finds the lock held by another instance for the first refusals
+// attempts, or for every attempt when refusals is negative.
+type lockerStub struct {
+	refusals int32
+	attempts atomic.Int32
+	unlocked atomic.Bool
+}
+
+func (l *lockerStub) TryLock(context.Context, string, time.Duration) (func(), bool, error) {
+	if attempt := l.attempts.Add(1); l.refusals < 0 || attempt <= l.refusals {
+		return nil, false, nil
+	}
+	return func() { l.unlocked.Store(true) }, true, nil
+}
+
+func TestLoad_SharesConcurrentLoads(t *testing.T) {
+	coalescer := NewCoalescer(nil, 0)
+	release := make(chan struct{})
+	var loads atomic.Int32
+
+	load := func(context.Context) (string, error) {
+		loads.Add(1)
+		<-release
+		return "sunny", nil
+	}
+	lookup := func(context.Context) (string, bool, error) { return "", false, nil }
+
+	var wg sync.WaitGroup
+	results := make([]string, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := Load(context.Background(), coalescer, "weather:kyiv", lookup, load)
+			assert.NoError(t, err)
+			results[i] = value
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+	assert.Equal(t, []string{"sunny", "sunny", "sunny", "sunny", "sunny"}, results)
+}
+
+func TestLoad_LockHolderRechecksCache(t *testing.T) {
+	locker := &lockerStub{}
+	coalescer := NewCoalescer(locker, time.Second)
+
+	value, err := Load(context.Background(), coalescer, "weather:kyiv",
+		func(context.Context) (string, bool, error) { return "cached", true, nil },
+		func(context.Context) (string, error) { return "loaded", nil },
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "cached", value)
+	assert.True(t, locker.unlocked.Load())
+}
+
+func TestLoad_WaitsForLockHolder(t *testing.T) {
+	coalescer := NewCoalescer(&lockerStub{refusals: -1}, time.Second)
+	var lookups atomic.Int32
+
+	value, err := Load(context.Background(), coalescer, "weather:kyiv",
+		func(context.Context) (string, bool, error) {
+			return "stored by another instance", lookups.Add(1) >= 2, nil
+		},
+		func(context.Context) (string, error) { return "loaded", nil },
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "stored by another instance", value)
+}
+
+func TestLoad_TakesOverWhenLockHolderReleasesWithoutValue(t *testing.T) {
+	locker := &lockerStub{refusals: 2}
+	coalescer := NewCoalescer(locker, time.Minute)
+
+	start := time.Now()
+	value, err := Load(context.Background(), coalescer, "weather:kyiv",
+		func(context.Context) (string, bool, error) { return "", false, nil },
+		func(context.Context) (string, error) { return "loaded", nil },
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "loaded", value)
+	assert.True(t, locker.unlocked.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestLoad_StopsWaitingForLockWhenCallerGivesUp(t *testing.T) {
+	coalescer := NewCoalescer(&lockerStub{refusals: -1}, time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var loads atomic.Int32
+
+	start := time.Now()
+	_, err := Load(ctx, coalescer, "weather:kyiv",
+		func(context.Context) (string, bool, error) { return "", false, nil },
+		func(context.Context) (string, error) {
+			loads.Add(1)
+			return "loaded", nil
+		},
+	)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Zero(t, loads.Load())
+}
+
+func TestLoad_WaitersReturnErrorsStoredByLockHolder(t *testing.T) {
+	coalescer := NewCoalescer(&lockerStub{refusals: -1}, time.Minute)
+	rejected := errors.New("city not found")
+	var loads atomic.Int32
+
+	start := time.Now()
+	_, err := Load(context.Background(), coalescer, "weather:atlantis",
+		func(context.Context) (string, bool, error) { return "", true, rejected },
+		func(context.Context) (string, error) {
+			loads.Add(1)
+			return "loaded", nil
+		},
+	)
+
+	assert.ErrorIs(t, err, rejected)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Zero(t, loads.Load())
+}
+
+func TestLoad_ReturnsWhenCallerGivesUp(t *testing.T) {
+	coalescer := NewCoalescer(nil, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := Load(ctx, coalescer, "weather:kyiv",
+		func(context.Context) (string, bool, error) { return "", false, nil },
+		func(context.Context) (string, error) {
+			time.Sleep(100 * time.Millisecond)
+			return "loaded", nil
+		},
+	)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLoad_CancelsSharedLoadWhenLastCallerGivesUp(t *testing.T) {
+	coalescer := NewCoalescer(nil, 0)
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return "", ctx.Err()
+	}
+	lookup := func(context.Context) (string, bool, error) { return "", false, nil }
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := Load(first, coalescer, "weather:kyiv", lookup, load)
+		errs <- err
+	}()
+	<-started
+	go func() {
+		_, err := Load(second, coalescer, "weather:kyiv", lookup, load)
+		errs <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancelFirst()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-cancelled:
+		t.Fatal("shared load was cancelled while a caller still waited on it")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("shared load was not cancelled after the last caller gave up")
+	}
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	CodecJSON    = "json"
+	CodecMsgpack = "msgpack"
+)
+
+const (
+	frameRaw byte = iota
+	frameGzip
+)
+
+// Codec turns cached values into bytes and back.
+type Codec interface {
+	Marshal(value any) ([]byte, error)
+	Unmarshal(data []byte, value any) error
+}
+
+// NewCodec returns the named codec. With compressAbove above zero, encoded
+// values of at least that many bytes are gzipped.
+func NewCodec(name string, compressAbove int) (Codec, error) {
+	var codec Codec
+	switch name {
+	case CodecJSON, "":
+		codec = JSONCodec{}
+	case CodecMsgpack:
+		codec = MsgpackCodec{}
+	default:
+		return nil, fmt.Errorf("unknown cache codec %q", name)
+	}
+
+	if compressAbove > 0 {
+		codec = NewCompressingCodec(codec, compressAbove)
+	}
+	return codec, nil
+}
+
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (JSONCodec) Unmarshal(data []byte, value any) error {
+	return json.Unmarshal(data, value)
+}
+
+// MsgpackCodec encodes values as MessagePack, naming fields after their json
+// tags so both codecs share the cached models.
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) Marshal(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (MsgpackCodec) Unmarshal(data []byte, value any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(value)
+}
+
+// CompressingCodec gzips values encoded by another codec once they reach a
+// threshold. Every value carries a leading byte telling whether it is gzipped.
+type CompressingCodec struct {
+	inner     Codec
+	threshold int
+}
+
+func NewCompressingCodec(inner Codec, threshold int) *CompressingCodec {
+	return &CompressingCodec{inner: inner, threshold: threshold}
+}
+
+func (c *CompressingCodec) Marshal(value any) ([]byte, error) {
+	data, err := c.inner.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < c.threshold {
+		return append([]byte{frameRaw}, data...), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(frameGzip)
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *CompressingCodec) Unmarshal(data []byte, value any) error {
+	if len(data) == 0 {
+		return fmt.Errorf("empty cache value")
+	}
+
+	switch data[0] {
+	case frameRaw:
+		return c.inner.Unmarshal(data[1:], value)
+	case frameGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return err
+		}
+		defer reader.Close() //nolint:errcheck
+		decoded, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		return c.inner.Unmarshal(decoded, value)
+	default:
+		return fmt.Errorf("unknown cache frame %d", data[0])
+	}
+}
@@ -0,0 +1,79 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedForecast struct {
+	City      string    `json:"city"`
+	TempC     float64   `json:"temp_c"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	want := cachedForecast{
+		City:      strings.Repeat("Kyiv", 100),
+		TempC:     21.5,
+		FetchedAt: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC),
+	}
+
+	for _, tc := range []struct {
+		name          string
+		codec         string
+		compressAbove int
+	}{
+		{name: "json", codec: CodecJSON},
+		{name: "msgpack", codec: CodecMsgpack},
+		{name: "json below threshold", codec: CodecJSON, compressAbove: 1 << 20},
+		{name: "msgpack gzipped", codec: CodecMsgpack, compressAbove: 64},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			codec, err := NewCodec(tc.codec, tc.compressAbove)
+			require.NoError(t, err)
+
+			data, err := codec.Marshal(want)
+			require.NoError(t, err)
+			var got cachedForecast
+			require.NoError(t, codec.Unmarshal(data, &got))
+
+			assert.Equal(t, want.City, got.City)
+			assert.Equal(t, want.TempC, got.TempC)
+			assert.True(t, want.FetchedAt.Equal(got.FetchedAt))
+		})
+	}
+}
+
+func TestCompressingCodec_CompressesLargeValues(t *testing.T) {
+	codec := NewCompressingCodec(JSONCodec{}, 64)
+	value := cachedForecast{City: strings.Repeat("Kyiv", 100)}
+
+	data, err := codec.Marshal(value)
+
+	require.NoError(t, err)
+	assert.Equal(t, frameGzip, data[0])
+	assert.Less(t, len(data), 100)
+}
+
+func TestCodecs_RejectForeignEncodings(t *testing.T) {
+	value := cachedForecast{City: "Kyiv"}
+	data, err := MsgpackCodec{}.Marshal(value)
+	require.NoError(t, err)
+
+	var got cachedForecast
+	assert.Error(t, JSONCodec{}.Unmarshal(data, &got))
+	assert.Error(t, NewCompressingCodec(JSONCodec{}, 64).Unmarshal(data, &got))
+}
+
+func TestNewCodec_RejectsUnknownCodec(t *testing.T) {
+	_, err := NewCodec("protobuf", 0)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+const TierMemory = "memory"
+
+// Health reports whether the shared cache backend can be reached.
+type Health interface {
+	Available() bool
+}
+
+type HealthRecorder interface {
+	BackendAvailable(available bool)
+}
+
+type noopHealthRecorder struct{}
+
+func (noopHealthRecorder) BackendAvailable(bool) {}
+
+// FailoverCache serves from an in-memory fallback while the primary backend is
+// down. The first failed call marks the primary down, so later calls skip it
+// instead of waiting for their own timeouts; Run brings it back once it
+// answers again.
+type FailoverCache struct {
+	primary  Cache
+	fallback *LocalCache
+	check    func(ctx context.Context) error
+	interval time.Duration
+	down     atomic.Bool
+	recorder HealthRecorder
+}
+
+func NewFailoverCache(
+	primary Cache,
+	fallback *LocalCache,
+	check func(ctx context.Context) error,
+	interval time.Duration,
+	recorder HealthRecorder,
+) *FailoverCache {
+	if recorder == nil {
+		recorder = noopHealthRecorder{}
+	}
+	return &FailoverCache{
+		primary:  primary,
+		fallback: fallback,
+		check:    check,
+		interval: interval,
+		recorder: recorder,
+	}
+}
+
+func (c *FailoverCache) Available() bool {
+	return !c.down.Load()
+}
+
+func (c *FailoverCache) Get(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	if c.Available() {
+		value, ttl, err := c.primary.Get(ctx, key)
+		if !c.failed(ctx, err) {
+			return value, ttl, err
+		}
+	}
+	return c.fallback.Get(ctx, key)
+}
+
+func (c *FailoverCache) Set(
+	ctx context.Context, key string, value []byte, ttl time.Duration,
+) error {
+	if c.Available() {
+		err := c.primary.Set(ctx, key, value, ttl)
+		if !c.failed(ctx, err) {
+			return err
+		}
+	}
+	return c.fallback.Set(ctx, key, value, ttl)
+}
+
+// Run probes the primary every interval until ctx is done, starting at once.
+func (c *FailoverCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.probe(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *FailoverCache) probe(ctx context.Context) {
+	probeCtx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	if err := c.check(probeCtx); err != nil {
+		if ctx.Err() == nil {
+			c.markDown(err)
+		}
+		return
+	}
+	c.markUp()
+}
+
+// failed reports whether err means the primary is down. Misses and callers
+// giving up say nothing about its health.
+func (c *FailoverCache) failed(ctx context.Context, err error) bool {
+	if err == nil || errors.Is(err, ErrMiss) || ctx.Err() != nil {
+		return false
+	}
+	c.markDown(err)
+	return true
+}
+
+func (c *FailoverCache) markDown(err error) {
+	if c.down.CompareAndSwap(false, true) {
+		log.Printf("cache: shared cache unavailable, serving from memory: %v\n", err)
+		c.recorder.BackendAvailable(false)
+	}
+}
+
+// markUp switches back to the primary. The fallback is cleared, as its entries
+// may have been replaced in the primary by other instances in the meantime.
+func (c *FailoverCache) markUp() {
+	if c.down.CompareAndSwap(true, false) {
+		log.Println("cache: shared cache available again")
+		c.fallback.Clear()
+	}
+	c.recorder.BackendAvailable(true)
+}
@@ -0,0 +1,92 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errConnRefused = errors.New("dial tcp: connection refused")
+
+type flakyCache struct {
+	Cache
+	down  atomic.Bool
+	calls atomic.Int32
+}
+
+func (c *flakyCache) Get(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	c.calls.Add(1)
+	if c.down.Load() {
+		return nil, 0, errConnRefused
+	}
+	return c.Cache.Get(ctx, key)
+}
+
+func (c *flakyCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.calls.Add(1)
+	if c.down.Load() {
+		return errConnRefused
+	}
+	return c.Cache.Set(ctx, key, value, ttl)
+}
+
+func (c *flakyCache) ping(context.Context) error {
+	if c.down.Load() {
+		return errConnRefused
+	}
+	return nil
+}
+
+func newFailoverCache() (*FailoverCache, *flakyCache) {
+	clock := &fakeClock{now: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)}
+	primary := &flakyCache{Cache: NewLocalCache(10, time.Hour, clock, nil)}
+	fallback := NewLocalCache(10, time.Hour, clock, nil)
+	return NewFailoverCache(primary, fallback, primary.ping, time.Second, nil), primary
+}
+
+func TestFailoverCache_BypassesPrimaryWhileDown(t *testing.T) {
+	failover, primary := newFailoverCache()
+	ctx := context.Background()
+	primary.down.Store(true)
+
+	require.NoError(t, failover.Set(ctx, "weather:kyiv", []byte("sunny"), time.Minute))
+	value, _, err := failover.Get(ctx, "weather:kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("sunny"), value)
+	assert.False(t, failover.Available())
+	assert.Equal(t, int32(1), primary.calls.Load())
+}
+
+func TestFailoverCache_MissesDoNotMarkPrimaryDown(t *testing.T) {
+	failover, _ := newFailoverCache()
+
+	_, _, err := failover.Get(context.Background(), "weather:kyiv")
+
+	assert.ErrorIs(t, err, ErrMiss)
+	assert.True(t, failover.Available())
+}
+
+func TestFailoverCache_RecoversAndDropsFallbackEntries(t *testing.T) {
+	failover, primary := newFailoverCache()
+	ctx := context.Background()
+	primary.down.Store(true)
+	require.NoError(t, failover.Set(ctx, "weather:kyiv", []byte("sunny"), time.Minute))
+
+	primary.down.Store(false)
+	failover.probe(ctx)
+	_, _, err := failover.Get(ctx, "weather:kyiv")
+
+	assert.True(t, failover.Available())
+	assert.ErrorIs(t, err, ErrMiss)
+	_, _, err = failover.fallback.Get(ctx, "weather:kyiv")
+	assert.ErrorIs(t, err, ErrMiss)
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyspaceEvents are the notify-keyspace-events flags the invalidator needs:
+// keyspace channels for string, generic, expired and evicted events.
+const keyspaceEvents = "K$gxe"
+
+const resubscribeDelay = time.Second
+
+// Invalidator evicts local entries when their key changes in Redis, so a value
+// rewritten or purged by another instance is not served from memory until its
+// local TTL runs out.
+type Invalidator struct {
+	client redis.UniversalClient
+	db     int
+	local  *LocalCache
+}
+
+func NewInvalidator(client redis.UniversalClient, db int, local *LocalCache) *Invalidator {
+	return &Invalidator{client: client, db: db, local: local}
+}
+
+// Run listens to keyspace events for keys under the given prefixes until ctx
+// is done. The local tier is cleared whenever the subscription is established,
+// so events missed while disconnected cannot leave stale entries behind.
+// Notifications are enabled on every (re)connection, since a restarted server
+// may have lost the setting.
+//
+// Keyspace events are only published on the node that holds the key, so in a
+// cluster every master gets its own subscription.
+func (i *Invalidator) Run(ctx context.Context, prefixes []string) {
+	patterns := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		patterns = append(patterns, fmt.Sprintf("%s%s:*", i.channelPrefix(), prefix))
+	}
+
+	for {
+		err := ForEachNode(ctx, i.client, func(ctx context.Context, node redis.UniversalClient) error {
+			i.listen(ctx, node, patterns)
+			return nil
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("cache: failed to reach redis nodes for keyspace events: %v\n", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (i *Invalidator) listen(ctx context.Context, node redis.UniversalClient, patterns []string) {
+	pubsub := node.PSubscribe(ctx, patterns...)
+	defer func() {
+		if err := pubsub.Close(); err != nil {
+			log.Printf("cache: failed to close keyspace subscription: %v\n", err)
+		}
+	}()
+
+	for {
+		message, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("cache: keyspace subscription error: %v\n", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(resubscribeDelay):
+			}
+			continue
+		}
+
+		i.handle(ctx, node, message)
+	}
+}
+
+func (i *Invalidator) handle(ctx context.Context, node redis.UniversalClient, message any) {
+	switch message := message.(type) {
+	case *redis.Subscription:
+		if message.Count == 1 {
+			if err := enableNotifications(ctx, node); err != nil {
+				log.Printf("cache: keyspace notifications unavailable: %v\n", err)
+			}
+		}
+		i.local.Clear()
+	case *redis.Message:
+		i.local.Delete(strings.TrimPrefix(message.Channel, i.channelPrefix()))
+	}
+}
+
+func (i *Invalidator) channelPrefix() string {
+	return fmt.Sprintf("__keyspace@%d__:", i.db)
+}
+
+// enableNotifications adds the flags the invalidator needs to the server's
+// notify-keyspace-events setting, keeping the ones already configured.
+func enableNotifications(ctx context.Context, node redis.UniversalClient) error {
+	current, err := node.ConfigGet(ctx, "notify-keyspace-events").Result()
+	if err != nil {
+		return err
+	}
+
+	flags := current["notify-keyspace-events"]
+	missing := ""
+	for _, flag := range keyspaceEvents {
+		if strings.ContainsRune(flags, flag) {
+			continue
+		}
+		if flag != 'K' && strings.ContainsRune(flags, 'A') {
+			continue
+		}
+		missing += string(flag)
+	}
+	if missing == "" {
+		return nil
+	}
+
+	return node.ConfigSet(ctx, "notify-keyspace-events", flags+missing).Err()
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type localEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// LocalCache is a size-bounded in-process LRU. Entries never outlive maxTTL,
+// whatever TTL they are stored with.
+type LocalCache struct {
+	mu       sync.Mutex
+	size     int
+	maxTTL   time.Duration
+	entries  map[string]*list.Element
+	order    *list.List
+	clock    Clock
+	recorder MetricsRecorder
+}
+
+func NewLocalCache(
+	size int, maxTTL time.Duration, clock Clock, recorder MetricsRecorder,
+) *LocalCache {
+	return &LocalCache{
+		size:     max(size, 1),
+		maxTTL:   maxTTL,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		clock:    clock,
+		recorder: recorderOrNoop(recorder),
+	}
+}
+
+func (c *LocalCache) Get(_ context.Context, key string) ([]byte, time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		c.recorder.CacheMiss()
+		return nil, 0, ErrMiss
+	}
+
+	entry := element.Value.(*localEntry)
+	remaining := entry.expiresAt.Sub(c.clock.Now())
+	if remaining <= 0 {
+		c.remove(element)
+		c.recorder.CacheMiss()
+		return nil, 0, ErrMiss
+	}
+
+	c.order.MoveToFront(element)
+	c.recorder.CacheHit()
+	return entry.value, remaining, nil
+}
+
+func (c *LocalCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 || ttl > c.maxTTL {
+		ttl = c.maxTTL
+	}
+	expiresAt := c.clock.Now().Add(ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*localEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&localEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+func (c *LocalCache) Delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+}
+
+func (c *LocalCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+func (c *LocalCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*localEntry).key)
+}
@@ -0,0 +1,89 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type countingRecorder struct {
+	hits   int
+	misses int
+}
+
+func (r *countingRecorder) CacheHit()  { r.hits++ }
+func (r *countingRecorder) CacheMiss() { r.misses++ }
+
+func newTestLocal(size int, maxTTL time.Duration) (*LocalCache, *fakeClock, *countingRecorder) {
+	clock := &fakeClock{now: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)}
+	recorder := &countingRecorder{}
+	return NewLocalCache(size, maxTTL, clock, recorder), clock, recorder
+}
+
+func TestLocalCache_CapsTTL(t *testing.T) {
+	local, clock, _ := newTestLocal(10, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, local.Set(ctx, "short", []byte("a"), 10*time.Second))
+	require.NoError(t, local.Set(ctx, "long", []byte("b"), time.Hour))
+
+	_, ttl, err := local.Get(ctx, "long")
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, ttl)
+
+	clock.now = clock.now.Add(30 * time.Second)
+	_, _, err = local.Get(ctx, "short")
+	assert.ErrorIs(t, err, ErrMiss)
+	value, _, err := local.Get(ctx, "long")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), value)
+
+	clock.now = clock.now.Add(time.Minute)
+	_, _, err = local.Get(ctx, "long")
+	assert.ErrorIs(t, err, ErrMiss)
+}
+
+func TestLocalCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	local, _, recorder := newTestLocal(2, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, local.Set(ctx, "a", []byte("a"), time.Minute))
+	require.NoError(t, local.Set(ctx, "b", []byte("b"), time.Minute))
+	_, _, err := local.Get(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, local.Set(ctx, "c", []byte("c"), time.Minute))
+
+	_, _, err = local.Get(ctx, "b")
+	assert.ErrorIs(t, err, ErrMiss)
+	_, _, err = local.Get(ctx, "a")
+	assert.NoError(t, err)
+	_, _, err = local.Get(ctx, "c")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, recorder.hits)
+	assert.Equal(t, 1, recorder.misses)
+}
+
+func TestLocalCache_Delete(t *testing.T) {
+	local, _, _ := newTestLocal(10, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, local.Set(ctx, "a", []byte("a"), time.Minute))
+	local.Delete("a", "missing")
+
+	_, _, err := local.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrMiss)
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisCache struct {
+	client     redis.UniversalClient
+	recorder   MetricsRecorder
+	operations OperationRecorder
+}
+
+func NewRedisCache(
+	client redis.UniversalClient, recorder MetricsRecorder, operations OperationRecorder,
+) *RedisCache {
+	if operations == nil {
+		operations = noopRecorder{}
+	}
+	return &RedisCache{
+		client:     client,
+		recorder:   recorderOrNoop(recorder),
+		operations: operations,
+	}
+}
+
+// Get reads the value and its remaining TTL in one round trip.
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	start := time.Now()
+	pipe := c.client.Pipeline()
+	get := pipe.Get(ctx, key)
+	pttl := pipe.PTTL(ctx, key)
+	_, err := pipe.Exec(ctx)
+
+	if errors.Is(get.Err(), redis.Nil) {
+		c.operations.ObserveOperation(OperationGet, time.Since(start), 0, nil)
+		c.recorder.CacheMiss()
+		return nil, 0, ErrMiss
+	}
+	c.operations.ObserveOperation(OperationGet, time.Since(start), len(get.Val()), err)
+	if err != nil {
+		log.Printf("redis: error for key=%s: %v\n", key, err)
+		return nil, 0, err
+	}
+
+	c.recorder.CacheHit()
+	log.Printf("redis: get key=%s success\n", key)
+	return []byte(get.Val()), max(pttl.Val(), 0), nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	start := time.Now()
+	err := c.client.Set(ctx, key, value, ttl).Err()
+	c.operations.ObserveOperation(OperationSet, time.Since(start), len(value), err)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("redis: set key=%s ttl=%s success\n", key, ttl.String())
+	return nil
+}
+
+// ForEachNode runs fn against every node that holds keys: each master of a
+// cluster, or the client itself otherwise. Commands such as SCAN and
+// PSUBSCRIBE on keyspace channels only see the node they are sent to.
+func ForEachNode(
+	ctx context.Context,
+	client redis.UniversalClient,
+	fn func(ctx context.Context, node redis.UniversalClient) error,
+) error {
+	cluster, ok := client.(*redis.ClusterClient)
+	if !ok {
+		return fn(ctx, client)
+	}
+	return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		return fn(ctx, node)
+	})
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const lockKeyPrefix = "lock:"
+
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type RedisLocker struct {
+	client redis.UniversalClient
+	health Health
+}
+
+func NewRedisLocker(client redis.UniversalClient, health Health) *RedisLocker {
+	return &RedisLocker{client: client, health: health}
+}
+
+// TryLock takes a lock that expires after ttl. Unlock only releases the lock
+// while it is still held by this caller. While Redis is down every caller gets
+// the lock at once, since there is no shared cache to wait on.
+func (l *RedisLocker) TryLock(
+	ctx context.Context, key string, ttl time.Duration,
+) (func(), bool, error) {
+	if l.health != nil && !l.health.Available() {
+		return func() {}, true, nil
+	}
+
+	lockKey := lockKeyPrefix + key
+	token := uuid.NewString()
+
+	ok, err := l.client.SetNX(ctx, lockKey, token, ttl).Result()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	unlock := func() {
+		err := unlockScript.Run(context.WithoutCancel(ctx), l.client, []string{lockKey}, token).Err()
+		if err != nil {
+			log.Printf("cache: failed to release lock %s: %v\n", lockKey, err)
+		}
+	}
+	return unlock, true, nil
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// TieredCache reads through an in-process tier before the shared one. Values
+// found in the shared tier are kept locally for no longer than they have left
+// there; writes go to the shared tier and drop the local copy.
+type TieredCache struct {
+	local  *LocalCache
+	shared Cache
+}
+
+func NewTieredCache(local *LocalCache, shared Cache) *TieredCache {
+	return &TieredCache{local: local, shared: shared}
+}
+
+func (c *TieredCache) Get(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	if value, ttl, err := c.local.Get(ctx, key); err == nil {
+		return value, ttl, nil
+	}
+
+	value, ttl, err := c.shared.Get(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	_ = c.local.Set(ctx, key, value, ttl)
+	return value, ttl, nil
+}
+
+func (c *TieredCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.shared.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	c.local.Delete(key)
+	return nil
+}
@@ -0,0 +1,87 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sharedStub struct {
+	values map[string][]byte
+	ttl    time.Duration
+	gets   int
+}
+
+func newSharedStub(ttl time.Duration) *sharedStub {
+	return &sharedStub{values: map[string][]byte{}, ttl: ttl}
+}
+
+func (s *sharedStub) Get(_ context.Context, key string) ([]byte, time.Duration, error) {
+	s.gets++
+	value, ok := s.values[key]
+	if !ok {
+		return nil, 0, ErrMiss
+	}
+	return value, s.ttl, nil
+}
+
+func (s *sharedStub) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	s.values[key] = value
+	return nil
+}
+
+func TestTieredCache_ServesRepeatedReadsLocally(t *testing.T) {
+	local, _, _ := newTestLocal(10, time.Minute)
+	shared := newSharedStub(time.Hour)
+	shared.values["weather:kyiv"] = []byte("sunny")
+	tiered := NewTieredCache(local, shared)
+	ctx := context.Background()
+
+	for range 3 {
+		value, _, err := tiered.Get(ctx, "weather:kyiv")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("sunny"), value)
+	}
+
+	assert.Equal(t, 1, shared.gets)
+}
+
+func TestTieredCache_LocalTTLFollowsSharedTTL(t *testing.T) {
+	local, clock, _ := newTestLocal(10, time.Minute)
+	shared := newSharedStub(10 * time.Second)
+	shared.values["weather:kyiv"] = []byte("sunny")
+	tiered := NewTieredCache(local, shared)
+	ctx := context.Background()
+
+	_, _, err := tiered.Get(ctx, "weather:kyiv")
+	require.NoError(t, err)
+
+	clock.now = clock.now.Add(15 * time.Second)
+	delete(shared.values, "weather:kyiv")
+
+	_, _, err = tiered.Get(ctx, "weather:kyiv")
+	assert.ErrorIs(t, err, ErrMiss)
+	assert.Equal(t, 2, shared.gets)
+}
+
+func TestTieredCache_SetDropsLocalCopy(t *testing.T) {
+	local, _, _ := newTestLocal(10, time.Minute)
+	shared := newSharedStub(time.Hour)
+	shared.values["weather:kyiv"] = []byte("sunny")
+	tiered := NewTieredCache(local, shared)
+	ctx := context.Background()
+
+	_, _, err := tiered.Get(ctx, "weather:kyiv")
+	require.NoError(t, err)
+	require.NoError(t, tiered.Set(ctx, "weather:kyiv", []byte("rainy"), time.Hour))
+
+	value, _, err := tiered.Get(ctx, "weather:kyiv")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("rainy"), value)
+}
@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	"weather-api/internal/domain"
+)
+
+func toAuditEntryEntity(entry *domain.AuditEntry) *AuditEntryEntity {
+	return &AuditEntryEntity{
+		Actor:      entry.Actor,
+		RemoteAddr: entry.RemoteAddr,
+		Action:     entry.Action,
+		Target:     entry.Target,
+		Outcome:    entry.Outcome,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"context"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	if err := r.db.WithContext(ctx).Create(toAuditEntryEntity(entry)).Error; err != nil {
+		return pkgErrors.New(internalErrors.ErrInternal, "failed to write audit entry")
+	}
+	return nil
+}
@@ -7,16 +7,50 @@ import (
 )
 
 type SubscriptionEntity struct {
-	ID        uint `gorm:"primaryKey"`
-	Email     string
-	City      string
-	Frequency domain.Frequency `gorm:"type:frequency_enum"`
-	Token     string
-	Confirmed bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           uint `gorm:"primaryKey"`
+	Email        string
+	City         string
+	Frequency    domain.Frequency `gorm:"type:frequency_enum"`
+	DeliveryTime string
+	Timezone     string
+	Schedule     string
+	Token        string
+	Confirmed    bool
+	Paused       bool
+	NextRunAt    *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func (SubscriptionEntity) TableName() string {
 	return "subscriptions"
 }
+
+type LocationEntity struct {
+	ID        uint `gorm:"primaryKey"`
+	Query     string
+	Name      string
+	Latitude  float64
+	Longitude float64
+	Country   string
+	Timezone  string
+	CreatedAt time.Time
+}
+
+func (LocationEntity) TableName() string {
+	return "locations"
+}
+
+type AuditEntryEntity struct {
+	ID         uint `gorm:"primaryKey"`
+	Actor      string
+	RemoteAddr string
+	Action     string
+	Target     string
+	Outcome    string
+	CreatedAt  time.Time
+}
+
+func (AuditEntryEntity) TableName() string {
+	return "audit_log"
+}
@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"weather-api/internal/domain"
+)
+
+func toLocationEntity(query string, location *domain.Location) *LocationEntity {
+	return &LocationEntity{
+		Query:     query,
+		Name:      location.Name,
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+		Country:   location.Country,
+		Timezone:  location.Timezone,
+	}
+}
+
+func toLocationDomain(entity *LocationEntity) *domain.Location {
+	return &domain.Location{
+		Name:      entity.Name,
+		Latitude:  entity.Latitude,
+		Longitude: entity.Longitude,
+		Country:   entity.Country,
+		Timezone:  entity.Timezone,
+	}
+}
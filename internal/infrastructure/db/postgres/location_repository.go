@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LocationRepository deliberately ignores the request transaction. Resolved
+// locations are a best-effort cache whose errors callers only log, but a
+// failed statement aborts the whole Postgres transaction, so a swallowed
+// Save error would fail the subscription that triggered the lookup. A
+// resolved location also stays valid when that request rolls back.
+type LocationRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationRepository(db *gorm.DB) *LocationRepository {
+	return &LocationRepository{db: db}
+}
+
+func (r *LocationRepository) FindByQuery(
+	ctx context.Context, query string,
+) (*domain.Location, error) {
+	var entity LocationEntity
+	result := r.db.WithContext(ctx).Where("query = ?", query).First(&entity)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, pkgErrors.New(
+			internalErrors.ErrInternal, "failed to find location by query",
+		)
+	}
+
+	return toLocationDomain(&entity), nil
+}
+
+func (r *LocationRepository) Save(
+	ctx context.Context, query string, location *domain.Location,
+) error {
+	entity := toLocationEntity(query, location)
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "query"}}, DoNothing: true}).
+		Create(entity).Error
+	if err != nil {
+		return pkgErrors.New(internalErrors.ErrInternal, "failed to save location")
+	}
+	return nil
+}
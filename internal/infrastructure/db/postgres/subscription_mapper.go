@@ -10,14 +10,19 @@ func toEntity(subscription *domain.Subscription) *SubscriptionEntity {
 	}
 
 	return &SubscriptionEntity{
-		ID:        subscription.ID,
-		Email:     subscription.Email,
-		City:      subscription.City,
-		Frequency: subscription.Frequency,
-		Token:     subscription.Token,
-		Confirmed: subscription.Confirmed,
-		CreatedAt: subscription.CreatedAt,
-		UpdatedAt: subscription.UpdatedAt,
+		ID:           subscription.ID,
+		Email:        subscription.Email,
+		City:         subscription.City,
+		Frequency:    subscription.Frequency,
+		DeliveryTime: subscription.DeliveryTime,
+		Timezone:     subscription.Timezone,
+		Schedule:     subscription.Schedule,
+		Token:        subscription.Token,
+		Confirmed:    subscription.Confirmed,
+		Paused:       subscription.Paused,
+		NextRunAt:    subscription.NextRunAt,
+		CreatedAt:    subscription.CreatedAt,
+		UpdatedAt:    subscription.UpdatedAt,
 	}
 }
 
@@ -27,14 +32,19 @@ func toDomain(entity *SubscriptionEntity) (*domain.Subscription, error) {
 	}
 
 	subscription := &domain.Subscription{
-		ID:        entity.ID,
-		Email:     entity.Email,
-		City:      entity.City,
-		Frequency: entity.Frequency,
-		Token:     entity.Token,
-		Confirmed: entity.Confirmed,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
+		ID:           entity.ID,
+		Email:        entity.Email,
+		City:         entity.City,
+		Frequency:    entity.Frequency,
+		DeliveryTime: entity.DeliveryTime,
+		Timezone:     entity.Timezone,
+		Schedule:     entity.Schedule,
+		Token:        entity.Token,
+		Confirmed:    entity.Confirmed,
+		Paused:       entity.Paused,
+		NextRunAt:    entity.NextRunAt,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
 	}
 
 	return subscription, nil
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"time"
 
 	"weather-api/internal/domain"
 	internalErrors "weather-api/internal/errors"
@@ -48,7 +50,8 @@ func (r *SubscriptionRepository) ExistByLookup(
 	db := r.getDB(ctx)
 	err := db.
 		Model(&SubscriptionEntity{}).
-		Where("email = ? AND city = ? AND frequency = ?", lookup.Email, lookup.City, lookup.Frequency).
+		Where("LOWER(email) = LOWER(?) AND city = ? AND frequency = ?",
+			lookup.Email, lookup.City, lookup.Frequency).
 		Count(&count).Error
 	if err != nil {
 		return false, pkgErrors.New(
@@ -112,6 +115,47 @@ func (r *SubscriptionRepository) FindByToken(
 	return toDomain(&entity)
 }
 
+// FindByEmail returns every subscription of the address, oldest first. Emails
+// are matched case-insensitively.
+func (r *SubscriptionRepository) FindByEmail(
+	ctx context.Context, email string,
+) ([]*domain.Subscription, error) {
+	var subscriptions []SubscriptionEntity
+	db := r.getDB(ctx)
+
+	err := db.
+		Where("LOWER(email) = LOWER(?)", email).
+		Order("created_at, id").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, pkgErrors.New(
+			internalErrors.ErrInternal, "failed to find subscriptions by email",
+		)
+	}
+
+	return toDomainList(subscriptions)
+}
+
+// FindByIDAndEmail returns the subscription only if it belongs to the address.
+func (r *SubscriptionRepository) FindByIDAndEmail(
+	ctx context.Context, id uint, email string,
+) (*domain.Subscription, error) {
+	var entity SubscriptionEntity
+	db := r.getDB(ctx)
+	result := db.Where("id = ? AND LOWER(email) = LOWER(?)", id, email).First(&entity)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, pkgErrors.New(
+			internalErrors.ErrInternal, "failed to find subscription",
+		)
+	}
+
+	return toDomain(&entity)
+}
+
 func (r *SubscriptionRepository) FindGroupedSubscriptions(
 	ctx context.Context, frequency *domain.Frequency,
 ) ([]*domain.GroupedSubscription, error) {
@@ -119,7 +163,7 @@ func (r *SubscriptionRepository) FindGroupedSubscriptions(
 	db := r.getDB(ctx)
 
 	err := db.
-		Where("confirmed = ? AND frequency = ?", true, frequency).
+		Where("confirmed = ? AND paused = ? AND frequency = ?", true, false, frequency).
 		Find(&subscriptions).Error
 	if err != nil {
 		return nil, pkgErrors.New(
@@ -127,6 +171,90 @@ func (r *SubscriptionRepository) FindGroupedSubscriptions(
 		)
 	}
 
+	return groupByCity(subscriptions)
+}
+
+// dueClause matches subscriptions whose delivery time, in their own timezone,
+// falls in (@from, @to]. The delivery instant is computed on the local dates
+// of both bounds, so a window spanning local midnight is covered.
+const dueClause = `EXISTS (
+	SELECT 1
+	FROM (VALUES (CAST(@from AS timestamptz)), (CAST(@to AS timestamptz))) AS bound(moment),
+	LATERAL (
+		SELECT ((bound.moment AT TIME ZONE subscriptions.timezone)::date
+			+ CAST(subscriptions.delivery_time AS time)) AT TIME ZONE subscriptions.timezone AS instant
+	) AS delivery
+	WHERE delivery.instant > @from AND delivery.instant <= @to
+)`
+
+// FindDueSubscriptions returns the confirmed, unpaused daily subscriptions whose local
+// delivery time falls in (from, to], grouped by city.
+func (r *SubscriptionRepository) FindDueSubscriptions(
+	ctx context.Context, from, to time.Time,
+) ([]*domain.GroupedSubscription, error) {
+	var subscriptions []SubscriptionEntity
+	db := r.getDB(ctx)
+
+	err := db.
+		Where("confirmed = ? AND paused = ? AND frequency = ?", true, false, domain.FrequencyDaily).
+		Where(dueClause, sql.Named("from", from), sql.Named("to", to)).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, pkgErrors.New(
+			internalErrors.ErrInternal, "failed to find due subscriptions",
+		)
+	}
+
+	return groupByCity(subscriptions)
+}
+
+// FindScheduledSubscriptions returns the confirmed, unpaused custom
+// subscriptions that may fire by the given time: those whose next run has
+// come and those whose next run is not computed yet. Grouped by city.
+func (r *SubscriptionRepository) FindScheduledSubscriptions(
+	ctx context.Context, until time.Time,
+) ([]*domain.GroupedSubscription, error) {
+	var subscriptions []SubscriptionEntity
+	db := r.getDB(ctx)
+
+	err := db.
+		Where("confirmed = ? AND paused = ? AND frequency = ?", true, false, domain.FrequencyCustom).
+		Where("next_run_at IS NULL OR next_run_at <= ?", until).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, pkgErrors.New(
+			internalErrors.ErrInternal, "failed to find scheduled subscriptions",
+		)
+	}
+
+	return groupByCity(subscriptions)
+}
+
+// SetNextRuns stores when each custom subscription, by ID, fires next.
+func (r *SubscriptionRepository) SetNextRuns(ctx context.Context, runs map[uint]time.Time) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	db := r.getDB(ctx)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for id, next := range runs {
+			err := tx.Model(&SubscriptionEntity{}).
+				Where("id = ?", id).
+				UpdateColumn("next_run_at", next).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return pkgErrors.New(internalErrors.ErrInternal, "failed to set next runs")
+	}
+	return nil
+}
+
+func groupByCity(subscriptions []SubscriptionEntity) ([]*domain.GroupedSubscription, error) {
 	subscriptionMap := make(map[string][]SubscriptionEntity)
 	for _, sub := range subscriptions {
 		subscriptionMap[sub.City] = append(subscriptionMap[sub.City], sub)
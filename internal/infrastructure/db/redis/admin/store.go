@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"weather-api/internal/application/common"
+	"weather-api/internal/infrastructure/db/cache"
+	appRedis "weather-api/internal/infrastructure/db/redis"
+)
+
+const (
+	scanCount   = 100
+	deleteBatch = 500
+)
+
+// Store gives administrative access to cached entries. Cache keys have the
+// form prefix:segment[:segment...], with the normalized city as one segment.
+type Store struct {
+	client redis.UniversalClient
+	codec  cache.Codec
+}
+
+func NewStore(client redis.UniversalClient, codec cache.Codec) *Store {
+	return &Store{client: client, codec: codec}
+}
+
+// Keys lists the keys under prefix, restricted to the given city unless it is
+// empty.
+func (s *Store) Keys(ctx context.Context, prefix, city string) ([]string, error) {
+	city = appRedis.CityKey(city)
+
+	var mu sync.Mutex
+	var keys []string
+	collect := func(ctx context.Context, node redis.UniversalClient) error {
+		found, err := scan(ctx, node, prefix, city)
+		mu.Lock()
+		keys = append(keys, found...)
+		mu.Unlock()
+		return err
+	}
+	if err := cache.ForEachNode(ctx, s.client, collect); err != nil {
+		return nil, err
+	}
+
+	slices.Sort(keys)
+	return slices.Compact(keys), nil
+}
+
+func scan(ctx context.Context, node redis.UniversalClient, prefix, city string) ([]string, error) {
+	var keys []string
+	iter := node.Scan(ctx, 0, prefix+":*", scanCount).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		segments := strings.Split(strings.TrimPrefix(key, prefix+":"), ":")
+		if city == "" || slices.Contains(segments, city) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, iter.Err()
+}
+
+// Entry returns the cached value as JSON where the codec can decode it, with
+// its remaining TTL, or nil when the key does not exist.
+func (s *Store) Entry(ctx context.Context, key string) (*common.CacheEntry, error) {
+	pipe := s.client.Pipeline()
+	get := pipe.Get(ctx, key)
+	pttl := pipe.PTTL(ctx, key)
+	_, err := pipe.Exec(ctx)
+
+	if errors.Is(get.Err(), redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &common.CacheEntry{
+		Key:   key,
+		Value: s.readable([]byte(get.Val())),
+		TTL:   max(pttl.Val(), 0),
+	}, nil
+}
+
+// Delete removes keys with one DEL each, pipelined in batches, so that keys
+// from different cluster slots can share a batch.
+func (s *Store) Delete(ctx context.Context, keys []string) (int, error) {
+	deleted := 0
+	for batch := range slices.Chunk(keys, deleteBatch) {
+		pipe := s.client.Pipeline()
+		results := make([]*redis.IntCmd, 0, len(batch))
+		for _, key := range batch {
+			results = append(results, pipe.Del(ctx, key))
+		}
+		_, err := pipe.Exec(ctx)
+		for _, result := range results {
+			deleted += int(result.Val())
+		}
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// readable re-encodes values of binary codecs as JSON. Values the codec cannot
+// decode are returned as they are.
+func (s *Store) readable(raw []byte) []byte {
+	if json.Valid(raw) {
+		return raw
+	}
+
+	var value any
+	if err := s.codec.Unmarshal(raw, &value); err != nil {
+		return raw
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return raw
+	}
+	return data
+}
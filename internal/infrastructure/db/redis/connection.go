@@ -1,7 +1,10 @@
 package redis
 
 import (
-	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -15,19 +18,93 @@ const (
 	dialTimeout  = 3 * time.Second
 )
 
-func NewClient(ctx context.Context, cfg config.RedisConfig) (*redis.Client, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:         cfg.Address,
-		Password:     cfg.Password,
-		DB:           cfg.DB,
-		ReadTimeout:  readTimeout,
-		WriteTimeout: writeTimeout,
-		DialTimeout:  dialTimeout,
-	})
-
-	err := client.Ping(ctx).Err()
+// NewClient connects lazily, so the service can start while Redis is down.
+// The returned client talks to a single node, a Sentinel-managed master or a
+// cluster depending on cfg.Mode.
+func NewClient(cfg config.RedisConfig) (redis.UniversalClient, error) {
+	tlsConfig, err := newTLSConfig(cfg.TLS)
 	if err != nil {
 		return nil, err
 	}
-	return client, nil
+
+	switch cfg.Mode {
+	case config.RedisStandalone, "":
+		return redis.NewClient(&redis.Options{
+			Addr:         cfg.Address,
+			Username:     cfg.Username,
+			Password:     cfg.Password,
+			DB:           cfg.DB,
+			TLSConfig:    tlsConfig,
+			ReadTimeout:  readTimeout,
+			WriteTimeout: writeTimeout,
+			DialTimeout:  dialTimeout,
+		}), nil
+	case config.RedisSentinel:
+		if cfg.MasterName == "" || len(cfg.Addresses) == 0 {
+			return nil, fmt.Errorf("redis sentinel mode needs a master name and sentinel addresses")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.Addresses,
+			SentinelPassword: cfg.SentinelPassword,
+			Username:         cfg.Username,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+			TLSConfig:        tlsConfig,
+			ReadTimeout:      readTimeout,
+			WriteTimeout:     writeTimeout,
+			DialTimeout:      dialTimeout,
+		}), nil
+	case config.RedisCluster:
+		if len(cfg.Addresses) == 0 {
+			return nil, fmt.Errorf("redis cluster mode needs at least one node address")
+		}
+		if cfg.DB != 0 {
+			return nil, fmt.Errorf("redis cluster mode only supports DB 0")
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.Addresses,
+			Username:     cfg.Username,
+			Password:     cfg.Password,
+			TLSConfig:    tlsConfig,
+			ReadTimeout:  readTimeout,
+			WriteTimeout: writeTimeout,
+			DialTimeout:  dialTimeout,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown redis mode %q", cfg.Mode)
+	}
+}
+
+// newTLSConfig returns nil when TLS is disabled. Without a CA file the system
+// roots are trusted; a certificate and key enable client authentication.
+func newTLSConfig(cfg config.RedisTLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // #nosec G402 -- opt-in for test setups
+	}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile) // #nosec G304 -- path comes from configuration
+		if err != nil {
+			return nil, fmt.Errorf("failed to read redis CA file: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("redis CA file %s contains no certificates", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load redis client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
 }
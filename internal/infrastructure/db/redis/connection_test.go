@@ -0,0 +1,101 @@
+//go:build unit
+// +build unit
+
+package redis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/config"
+)
+
+func TestNewClient_Modes(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.RedisConfig
+		want any
+	}{
+		{
+			name: "standalone by default",
+			cfg:  config.RedisConfig{Address: "localhost:6379"},
+			want: &redis.Client{},
+		},
+		{
+			name: "sentinel",
+			cfg: config.RedisConfig{
+				Mode:       config.RedisSentinel,
+				Addresses:  []string{"sentinel-1:26379", "sentinel-2:26379"},
+				MasterName: "mymaster",
+			},
+			want: &redis.Client{},
+		},
+		{
+			name: "cluster",
+			cfg: config.RedisConfig{
+				Mode:      config.RedisCluster,
+				Addresses: []string{"node-1:6379", "node-2:6379"},
+			},
+			want: &redis.ClusterClient{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.cfg)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = client.Close() })
+
+			assert.IsType(t, tt.want, client)
+		})
+	}
+}
+
+func TestNewClient_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.RedisConfig
+	}{
+		{name: "unknown mode", cfg: config.RedisConfig{Mode: "replicated"}},
+		{name: "sentinel without master", cfg: config.RedisConfig{
+			Mode: config.RedisSentinel, Addresses: []string{"sentinel:26379"},
+		}},
+		{name: "cluster without nodes", cfg: config.RedisConfig{Mode: config.RedisCluster}},
+		{name: "cluster with db", cfg: config.RedisConfig{
+			Mode: config.RedisCluster, Addresses: []string{"node:6379"}, DB: 1,
+		}},
+		{name: "missing CA file", cfg: config.RedisConfig{
+			TLS: config.RedisTLSConfig{Enabled: true, CAFile: "missing.pem"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.cfg)
+
+			assert.Error(t, err)
+			assert.Nil(t, client)
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	disabled, err := newTLSConfig(config.RedisTLSConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, disabled)
+
+	enabled, err := newTLSConfig(config.RedisTLSConfig{Enabled: true, ServerName: "redis.internal"})
+	require.NoError(t, err)
+	assert.Equal(t, "redis.internal", enabled.ServerName)
+	assert.Nil(t, enabled.RootCAs)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	_, err = newTLSConfig(config.RedisTLSConfig{Enabled: true, CAFile: caFile})
+	assert.Error(t, err)
+}
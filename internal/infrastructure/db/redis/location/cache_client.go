@@ -0,0 +1,79 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"weather-api/internal/domain"
+	"weather-api/internal/infrastructure/db/cache"
+	appRedis "weather-api/internal/infrastructure/db/redis"
+)
+
+type Client interface {
+	Resolve(ctx context.Context, city string) (*domain.Location, error)
+}
+
+// schemaVersion is part of every key; bump it when domain.Location changes.
+const schemaVersion = 1
+
+// entryType labels the location cache metrics.
+const entryType = "location"
+
+type MetricsRecorder interface {
+	CacheResult(prefix, entryType, result string)
+}
+
+type ProxyClient struct {
+	delegate Client
+	cache    cache.Cache
+	codec    cache.Codec
+	ttl      time.Duration
+	prefix   string
+	recorder MetricsRecorder
+}
+
+func NewProxyClient(
+	delegate Client,
+	store cache.Cache,
+	codec cache.Codec,
+	ttl time.Duration,
+	prefix string,
+	recorder MetricsRecorder,
+) *ProxyClient {
+	return &ProxyClient{
+		delegate: delegate,
+		cache:    store,
+		codec:    codec,
+		ttl:      ttl,
+		prefix:   prefix,
+		recorder: recorder,
+	}
+}
+
+func (c *ProxyClient) Resolve(ctx context.Context, city string) (*domain.Location, error) {
+	key := appRedis.Key(c.prefix, schemaVersion, appRedis.CityKey(city))
+
+	cached, err := appRedis.Get[domain.Location](ctx, c.cache, c.codec, key)
+	switch {
+	case err == nil:
+		c.recorder.CacheResult(c.prefix, entryType, cache.ResultHit)
+		return cached, nil
+	case errors.Is(err, appRedis.ErrUndecodable):
+		c.recorder.CacheResult(c.prefix, entryType, cache.ResultUndecodable)
+	case errors.Is(err, cache.ErrMiss):
+		c.recorder.CacheResult(c.prefix, entryType, cache.ResultMiss)
+	}
+
+	resolved, err := c.delegate.Resolve(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := appRedis.Set(ctx, c.cache, c.codec, key, resolved, c.ttl); err != nil {
+		log.Printf("location: failed to cache location for key %s: %v\n", key, err)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,131 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	windowHourly = "hourly"
+	windowDaily  = "daily"
+	keyPrefix    = "quota"
+)
+
+var allowScript = redis.NewScript(`
+local hourly = tonumber(redis.call('GET', KEYS[1]) or '0')
+local daily = tonumber(redis.call('GET', KEYS[2]) or '0')
+local hourlyLimit = tonumber(ARGV[1])
+local dailyLimit = tonumber(ARGV[2])
+
+if (hourlyLimit > 0 and hourly >= hourlyLimit) or (dailyLimit > 0 and daily >= dailyLimit) then
+	return {0, hourly, daily}
+end
+
+hourly = redis.call('INCR', KEYS[1])
+if hourly == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+end
+
+daily = redis.call('INCR', KEYS[2])
+if daily == 1 then
+	redis.call('PEXPIRE', KEYS[2], ARGV[4])
+end
+
+return {1, hourly, daily}
+`)
+
+type Clock interface {
+	Now() time.Time
+}
+
+type Health interface {
+	Available() bool
+}
+
+type MetricsRecorder interface {
+	QuotaRemaining(provider, window string, remaining int)
+}
+
+type Limiter struct {
+	redis    redis.UniversalClient
+	health   Health
+	clock    Clock
+	recorder MetricsRecorder
+}
+
+func NewLimiter(
+	redisClient redis.UniversalClient, health Health, clock Clock, recorder MetricsRecorder,
+) *Limiter {
+	return &Limiter{
+		redis:    redisClient,
+		health:   health,
+		clock:    clock,
+		recorder: recorder,
+	}
+}
+
+func (l *Limiter) Budget(provider, group string, hourly, daily int) *Budget {
+	return &Budget{
+		limiter:  l,
+		provider: provider,
+		group:    group,
+		hourly:   hourly,
+		daily:    daily,
+	}
+}
+
+// Budget tracks a provider's request allowance in fixed hourly and daily
+// windows shared by every instance through Redis. A zero limit is unlimited.
+type Budget struct {
+	limiter  *Limiter
+	provider string
+	group    string
+	hourly   int
+	daily    int
+}
+
+// Allow takes one request from the budget. Requests are allowed when Redis
+// cannot be asked, whether it is known to be down or the call fails.
+func (b *Budget) Allow(ctx context.Context) bool {
+	if b.limiter.health != nil && !b.limiter.health.Available() {
+		return true
+	}
+
+	now := b.limiter.clock.Now().UTC()
+	hourStart := now.Truncate(time.Hour)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	// The group is a hash tag so both keys land in one cluster slot, as the
+	// script touching them requires.
+	keys := []string{
+		fmt.Sprintf("%s:{%s}:%s:%s", keyPrefix, b.group, windowHourly, hourStart.Format("2006010215")),
+		fmt.Sprintf("%s:{%s}:%s:%s", keyPrefix, b.group, windowDaily, dayStart.Format("20060102")),
+	}
+	hourTTL := hourStart.Add(time.Hour).Sub(now)
+	dayTTL := dayStart.AddDate(0, 0, 1).Sub(now)
+
+	result, err := allowScript.Run(
+		ctx, b.limiter.redis, keys,
+		b.hourly, b.daily, hourTTL.Milliseconds()+1, dayTTL.Milliseconds()+1,
+	).Int64Slice()
+	if err != nil {
+		log.Printf("quota: failed to check budget for %s, allowing request: %v\n", b.provider, err)
+		return true
+	}
+
+	b.record(windowHourly, b.hourly, result[1])
+	b.record(windowDaily, b.daily, result[2])
+
+	return result[0] == 1
+}
+
+func (b *Budget) record(window string, limit int, used int64) {
+	if limit <= 0 || b.limiter.recorder == nil {
+		return
+	}
+	b.limiter.recorder.QuotaRemaining(b.provider, window, max(limit-int(used), 0))
+}
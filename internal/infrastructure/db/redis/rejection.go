@@ -0,0 +1,31 @@
+package redis
+
+import (
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+// Rejection is a cached negative answer: a provider's verdict that a city does
+// not exist or is not valid.
+type Rejection struct {
+	Class   string `json:"class"`
+	Message string `json:"message"`
+}
+
+// NewRejection returns the rejection to cache for err, or nil when err says
+// nothing definite about the city itself.
+func NewRejection(err error) *Rejection {
+	switch class := internalErrors.Class(err); class {
+	case internalErrors.ClassNotFound, internalErrors.ClassInvalidInput:
+		return &Rejection{Class: class, Message: err.Error()}
+	default:
+		return nil
+	}
+}
+
+func (r *Rejection) Err() error {
+	if r.Class == internalErrors.ClassInvalidInput {
+		return pkgErrors.New(internalErrors.ErrInvalidInput, r.Message)
+	}
+	return pkgErrors.New(internalErrors.ErrNotFound, r.Message)
+}
@@ -2,46 +2,57 @@ package redis
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"weather-api/internal/infrastructure/db/cache"
 )
 
-func Get[T any](ctx context.Context, client *redis.Client, key string) (*T, error) {
-	raw, err := client.Get(ctx, key).Result()
+// ErrUndecodable is returned for entries the codec cannot read, such as ones
+// written by another codec. It counts as a cache miss.
+var ErrUndecodable = fmt.Errorf("%w: undecodable entry", cache.ErrMiss)
+
+// Key builds a cache key under prefix. The schema version keeps entries of
+// older cached models from being read once the models change.
+func Key(prefix string, version int, parts ...string) string {
+	return fmt.Sprintf("%s:v%d:%s", prefix, version, strings.Join(parts, ":"))
+}
+
+// CityKey normalizes a city name for use in cache keys, so every proxy files
+// " Kyiv" and "kyiv" under the same entry.
+func CityKey(city string) string {
+	return strings.ToLower(strings.TrimSpace(city))
+}
+
+func Get[T any](ctx context.Context, store cache.Cache, codec cache.Codec, key string) (*T, error) {
+	raw, _, err := store.Get(ctx, key)
 	if err != nil {
-		log.Printf("redis: error for key=%s: %v\n", key, err)
 		return nil, err
 	}
 
 	var result T
-	if err := json.Unmarshal([]byte(raw), &result); err != nil {
-		log.Printf("redis: unmarshal error for key=%s: %v\n", key, err)
-		return nil, err
+	if err := codec.Unmarshal(raw, &result); err != nil {
+		log.Printf("redis: decode error for key=%s: %v\n", key, err)
+		return nil, ErrUndecodable
 	}
 
-	log.Printf("redis: get key=%s success\n", key)
 	return &result, nil
 }
 
 func Set(
 	ctx context.Context,
-	client *redis.Client,
+	store cache.Cache,
+	codec cache.Codec,
 	key string,
 	value any,
 	ttl time.Duration,
 ) error {
-	data, err := json.Marshal(value)
+	data, err := codec.Marshal(value)
 	if err != nil {
 		return err
 	}
 
-	if err := client.Set(ctx, key, data, ttl).Err(); err != nil {
-		return err
-	}
-
-	log.Printf("redis: set key=%s ttl=%s success\n", key, ttl.String())
-	return nil
+	return store.Set(ctx, key, data, ttl)
 }
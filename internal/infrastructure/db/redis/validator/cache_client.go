@@ -3,72 +3,135 @@ package validator
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"strings"
 	"time"
 
+	"weather-api/internal/infrastructure/db/cache"
 	appRedis "weather-api/internal/infrastructure/db/redis"
-
-	"github.com/redis/go-redis/v9"
 )
 
+// schemaVersion is part of every key; bump it when the cached value changes.
+const schemaVersion = 2
+
+// entryType labels the validator's cache metrics.
+const entryType = "validation"
+
 type Client interface {
 	Validate(ctx context.Context, city string) (*string, error)
 }
 
 type MetricsRecorder interface {
-	CacheHit()
-	CacheMiss()
+	CacheResult(prefix, entryType, result string)
+}
+
+// validation is a cached answer: the validated city, or the rejection of an
+// invalid one.
+type validation struct {
+	City      *string             `json:"city,omitempty"`
+	Rejection *appRedis.Rejection `json:"rejection,omitempty"`
 }
 
 type ProxyClient struct {
-	delegate Client
-	redis    *redis.Client
-	ttl      time.Duration
-	prefix   string
-	recorder MetricsRecorder
+	delegate    Client
+	cache       cache.Cache
+	codec       cache.Codec
+	coalescer   *cache.Coalescer
+	ttl         time.Duration
+	negativeTTL time.Duration
+	prefix      string
+	recorder    MetricsRecorder
 }
 
 func NewProxyClient(
 	delegate Client,
-	redisClient *redis.Client,
+	store cache.Cache,
+	codec cache.Codec,
+	coalescer *cache.Coalescer,
 	ttl time.Duration,
 	prefix string,
 	recorder MetricsRecorder,
 ) *ProxyClient {
 	return &ProxyClient{
-		delegate: delegate,
-		redis:    redisClient,
-		ttl:      ttl,
-		prefix:   prefix,
-		recorder: recorder,
+		delegate:  delegate,
+		cache:     store,
+		codec:     codec,
+		coalescer: coalescer,
+		ttl:       ttl,
+		prefix:    prefix,
+		recorder:  recorder,
 	}
 }
 
+// SetNegativeTTL caches rejected cities for ttl, so repeated lookups of the
+// same invalid city do not reach the provider. Zero disables it. A rejection
+// is overwritten as soon as a lookup of the city succeeds.
+func (c *ProxyClient) SetNegativeTTL(ttl time.Duration) {
+	c.negativeTTL = ttl
+}
+
 func (c *ProxyClient) Validate(ctx context.Context, city string) (*string, error) {
-	key := fmt.Sprintf("%s:%s", c.prefix, strings.ToLower(city))
+	key := appRedis.Key(c.prefix, schemaVersion, appRedis.CityKey(city))
 
-	cachedCity, err := appRedis.Get[string](ctx, c.redis, key)
-	if err == nil {
-		c.recorder.CacheHit()
-		return cachedCity, nil
+	cached, err := appRedis.Get[validation](ctx, c.cache, c.codec, key)
+	switch {
+	case err == nil && cached.Rejection != nil:
+		c.record(cache.ResultNegative)
+		return nil, cached.Rejection.Err()
+	case err == nil:
+		c.record(cache.ResultHit)
+		return cached.City, nil
+	case errors.Is(err, appRedis.ErrUndecodable):
+		c.record(cache.ResultUndecodable)
+	case errors.Is(err, cache.ErrMiss):
+		c.record(cache.ResultMiss)
 	}
 
-	if errors.Is(err, redis.Nil) {
-		c.recorder.CacheMiss()
+	// A rejection stored by another caller is as final as a validated city.
+	lookup := func(ctx context.Context) (*string, bool, error) {
+		cached, err := appRedis.Get[validation](ctx, c.cache, c.codec, key)
+		if err != nil {
+			return nil, false, nil
+		}
+		if cached.Rejection != nil {
+			return nil, true, cached.Rejection.Err()
+		}
+		return cached.City, true, nil
 	}
 
+	return cache.Load(ctx, c.coalescer, key, lookup, func(ctx context.Context) (*string, error) {
+		return c.load(ctx, key, city)
+	})
+}
+
+func (c *ProxyClient) load(ctx context.Context, key, city string) (*string, error) {
 	cityValidated, err := c.delegate.Validate(ctx, city)
 	if err != nil {
+		c.reject(ctx, key, err)
 		return nil, err
 	}
 
 	if cityValidated != nil {
-		if err := appRedis.Set(ctx, c.redis, key, cityValidated, c.ttl); err != nil {
+		entry := validation{City: cityValidated}
+		if err := appRedis.Set(ctx, c.cache, c.codec, key, entry, c.ttl); err != nil {
 			log.Printf("validator: failed to cache cityValidated for key %s: %v\n", key, err)
 		}
 	}
 
 	return cityValidated, nil
 }
+
+func (c *ProxyClient) reject(ctx context.Context, key string, err error) {
+	rejection := appRedis.NewRejection(err)
+	if rejection == nil || c.negativeTTL <= 0 {
+		return
+	}
+
+	entry := validation{Rejection: rejection}
+	if err := appRedis.Set(ctx, c.cache, c.codec, key, entry, c.negativeTTL); err != nil {
+		log.Printf("validator: failed to cache rejection for key %s: %v\n", key, err)
+	}
+}
+
+func (c *ProxyClient) record(result string) {
+	c.recorder.CacheResult(c.prefix, entryType, result)
+}
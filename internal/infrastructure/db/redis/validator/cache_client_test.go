@@ -0,0 +1,150 @@
+//go:build unit
+// +build unit
+
+package validator
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/infrastructure/db/cache"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type validatorStub struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (v *validatorStub) Validate(_ context.Context, city string) (*string, error) {
+	v.calls.Add(1)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return &city, nil
+}
+
+type metricsStub struct {
+	results []string
+}
+
+func (m *metricsStub) CacheResult(_, _, result string) {
+	m.results = append(m.results, result)
+}
+
+// heldLocker reports the cache lock as held by another instance.
+type heldLocker struct{}
+
+func (heldLocker) TryLock(context.Context, string, time.Duration) (func(), bool, error) {
+	return nil, false, nil
+}
+
+func newTestProxy(delegate Client) (*ProxyClient, *metricsStub, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)}
+	metrics := &metricsStub{}
+	proxy := NewProxyClient(
+		delegate,
+		cache.NewLocalCache(100, 24*time.Hour, clock, nil),
+		cache.JSONCodec{},
+		cache.NewCoalescer(nil, 0),
+		time.Hour,
+		"geo-coding",
+		metrics,
+	)
+	proxy.SetNegativeTTL(time.Minute)
+	return proxy, metrics, clock
+}
+
+func TestProxyClient_CachesRejectedCities(t *testing.T) {
+	delegate := &validatorStub{err: pkgErrors.New(internalErrors.ErrInvalidInput, "invalid city")}
+	proxy, metrics, _ := newTestProxy(delegate)
+
+	_, err := proxy.Validate(context.Background(), "Qwzx")
+	require.ErrorIs(t, err, internalErrors.ErrInvalidInput)
+	_, err = proxy.Validate(context.Background(), "qwzx")
+
+	assert.ErrorIs(t, err, internalErrors.ErrInvalidInput)
+	assert.Equal(t, "invalid city", err.Error())
+	assert.Equal(t, int32(1), delegate.calls.Load())
+	assert.Equal(t, []string{cache.ResultMiss, cache.ResultNegative}, metrics.results)
+}
+
+func TestProxyClient_ExpiresRejections(t *testing.T) {
+	delegate := &validatorStub{err: pkgErrors.New(internalErrors.ErrNotFound, "city not found")}
+	proxy, _, clock := newTestProxy(delegate)
+
+	_, err := proxy.Validate(context.Background(), "Kyiv")
+	require.ErrorIs(t, err, internalErrors.ErrNotFound)
+	delegate.err = nil
+	clock.now = clock.now.Add(2 * time.Minute)
+	city, err := proxy.Validate(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Kyiv", *city)
+	assert.Equal(t, int32(2), delegate.calls.Load())
+}
+
+func TestProxyClient_DoesNotCacheProviderFailures(t *testing.T) {
+	delegate := &validatorStub{err: pkgErrors.New(internalErrors.ErrServiceUnavailable, "timeout")}
+	proxy, _, _ := newTestProxy(delegate)
+
+	_, _ = proxy.Validate(context.Background(), "Kyiv")
+	_, err := proxy.Validate(context.Background(), "Kyiv")
+
+	assert.ErrorIs(t, err, internalErrors.ErrServiceUnavailable)
+	assert.Equal(t, int32(2), delegate.calls.Load())
+}
+
+func TestProxyClient_WaitersReturnRejectionStoredByLockHolder(t *testing.T) {
+	holderDelegate := &validatorStub{
+		err: pkgErrors.New(internalErrors.ErrInvalidInput, "invalid city"),
+	}
+	holder, _, _ := newTestProxy(holderDelegate)
+	waiterDelegate := &validatorStub{}
+	waiter, _, _ := newTestProxy(waiterDelegate)
+	waiter.cache = holder.cache
+	waiter.coalescer = cache.NewCoalescer(heldLocker{}, time.Minute)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := waiter.Validate(context.Background(), "Qwzx")
+		errs <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	_, err := holder.Validate(context.Background(), "Qwzx")
+	require.ErrorIs(t, err, internalErrors.ErrInvalidInput)
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, internalErrors.ErrInvalidInput)
+	case <-time.After(time.Second):
+		t.Fatal("waiter kept waiting despite the cached rejection")
+	}
+	assert.Zero(t, waiterDelegate.calls.Load())
+}
+
+func TestProxyClient_NormalizesCityKeys(t *testing.T) {
+	delegate := &validatorStub{}
+	proxy, _, _ := newTestProxy(delegate)
+
+	_, err := proxy.Validate(context.Background(), " Kyiv ")
+	require.NoError(t, err)
+	_, err = proxy.Validate(context.Background(), "kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), delegate.calls.Load())
+}
@@ -5,153 +5,352 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
-	"github.com/redis/go-redis/v9"
-
 	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/infrastructure/db/cache"
 	appRedis "weather-api/internal/infrastructure/db/redis"
 )
 
 type Client interface {
 	GetDailyForecast(ctx context.Context, city string) (*domain.WeatherDaily, error)
 	GetHourlyForecast(ctx context.Context, city string) (*domain.WeatherHourly, error)
+	GetHourlyTimeline(ctx context.Context, city string, hours int) (*domain.WeatherTimeline, error)
 	GetWeather(ctx context.Context, city string) (*domain.Weather, error)
 }
 
+// MetricsRecorder counts cache lookups by key prefix, forecast type and
+// result (one of the cache.Result constants).
 type MetricsRecorder interface {
-	CacheHit()
-	CacheMiss()
+	CacheResult(prefix, forecastType, result string)
+}
+
+type Clock interface {
+	Now() time.Time
+}
+
+// Staleness controls how long forecasts are kept once they stop being fresh.
+// Within WhileRevalidate an expired forecast is served at once and refreshed
+// in the background; within IfError StaleFallbackClient serves it when every
+// provider fails.
+type Staleness struct {
+	WhileRevalidate time.Duration
+	IfError         time.Duration
+}
+
+func (s Staleness) retention() time.Duration {
+	return max(s.WhileRevalidate, s.IfError)
 }
 
 type ProxyClient struct {
-	delegate Client
-	redis    *redis.Client
-	provider TTLProvider
-	prefix   string
-	recorder MetricsRecorder
+	delegate    Client
+	cache       cache.Cache
+	codec       cache.Codec
+	coalescer   *cache.Coalescer
+	provider    TTLProvider
+	clock       Clock
+	staleness   Staleness
+	negativeTTL time.Duration
+	prefix      string
+	recorder    MetricsRecorder
 }
 
 type ForecastType string
 
 const (
-	ForecastCurrent ForecastType = "current"
-	ForecastHourly  ForecastType = "hourly"
-	ForecastDaily   ForecastType = "daily"
+	ForecastCurrent  ForecastType = "current"
+	ForecastHourly   ForecastType = "hourly"
+	ForecastDaily    ForecastType = "daily"
+	ForecastTimeline ForecastType = "timeline"
 )
 
 type TTLProvider interface {
-	TTL(forecastType ForecastType) time.Duration
+	TTL(forecastType ForecastType, hints domain.Freshness) time.Duration
 }
 
 func NewProxyClient(
 	delegate Client,
-	redisClient *redis.Client,
+	store cache.Cache,
+	codec cache.Codec,
+	coalescer *cache.Coalescer,
 	provider TTLProvider,
+	clock Clock,
 	prefix string,
 	recorder MetricsRecorder,
 ) *ProxyClient {
 	return &ProxyClient{
-		delegate: delegate,
-		redis:    redisClient,
-		provider: provider,
-		prefix:   prefix,
-		recorder: recorder,
+		delegate:  delegate,
+		cache:     store,
+		codec:     codec,
+		coalescer: coalescer,
+		provider:  provider,
+		clock:     clock,
+		prefix:    prefix,
+		recorder:  recorder,
 	}
 }
 
+func (c *ProxyClient) SetStaleness(staleness Staleness) {
+	c.staleness = staleness
+}
+
+// SetNegativeTTL caches "not found" answers for ttl, so unknown cities do not
+// reach the provider on every request. Zero disables it. The entry is
+// overwritten as soon as a fetch of the city succeeds.
+func (c *ProxyClient) SetNegativeTTL(ttl time.Duration) {
+	c.negativeTTL = ttl
+}
+
 func (c *ProxyClient) GetDailyForecast(
 	ctx context.Context, city string,
 ) (*domain.WeatherDaily, error) {
-	key := c.getForecastKey(ForecastDaily, city)
+	return c.dailyLoad(city).get(ctx)
+}
 
-	cached, err := appRedis.Get[WeatherDaily](ctx, c.redis, key)
-	if err == nil {
-		c.recorder.CacheHit()
-		return ToDomainWeatherDaily(cached), nil
+func (c *ProxyClient) GetHourlyForecast(
+	ctx context.Context, city string,
+) (*domain.WeatherHourly, error) {
+	return c.hourlyLoad(city).get(ctx)
+}
+
+func (c *ProxyClient) GetHourlyTimeline(
+	ctx context.Context, city string, hours int,
+) (*domain.WeatherTimeline, error) {
+	return c.timelineLoad(city, hours).get(ctx)
+}
+
+func (c *ProxyClient) GetWeather(ctx context.Context, city string) (*domain.Weather, error) {
+	return c.currentLoad(city).get(ctx)
+}
+
+func (c *ProxyClient) dailyLoad(
+	city string,
+) *forecastLoad[domain.WeatherDaily, *domain.WeatherDaily, WeatherDaily] {
+	return &forecastLoad[domain.WeatherDaily, *domain.WeatherDaily, WeatherDaily]{
+		proxy:        c,
+		forecastType: ForecastDaily,
+		key:          c.getForecastKey(ForecastDaily, city),
+		load: func(ctx context.Context) (*domain.WeatherDaily, error) {
+			return c.delegate.GetDailyForecast(ctx, city)
+		},
+		toDTO:    ToDTOWeatherDaily,
+		toDomain: ToDomainWeatherDaily,
 	}
-	if errors.Is(err, redis.Nil) {
-		c.recorder.CacheMiss()
+}
+
+func (c *ProxyClient) hourlyLoad(
+	city string,
+) *forecastLoad[domain.WeatherHourly, *domain.WeatherHourly, WeatherHourly] {
+	return &forecastLoad[domain.WeatherHourly, *domain.WeatherHourly, WeatherHourly]{
+		proxy:        c,
+		forecastType: ForecastHourly,
+		key:          c.getForecastKey(ForecastHourly, city),
+		load: func(ctx context.Context) (*domain.WeatherHourly, error) {
+			return c.delegate.GetHourlyForecast(ctx, city)
+		},
+		toDTO:    ToDTOWeatherHourly,
+		toDomain: ToDomainWeatherHourly,
 	}
+}
 
-	weather, err := c.delegate.GetDailyForecast(ctx, city)
-	if err != nil {
-		return nil, err
+func (c *ProxyClient) timelineLoad(
+	city string, hours int,
+) *forecastLoad[domain.WeatherTimeline, *domain.WeatherTimeline, WeatherTimeline] {
+	return &forecastLoad[domain.WeatherTimeline, *domain.WeatherTimeline, WeatherTimeline]{
+		proxy:        c,
+		forecastType: ForecastTimeline,
+		key:          fmt.Sprintf("%s:%d", c.getForecastKey(ForecastTimeline, city), hours),
+		load: func(ctx context.Context) (*domain.WeatherTimeline, error) {
+			return c.delegate.GetHourlyTimeline(ctx, city, hours)
+		},
+		toDTO:    ToDTOWeatherTimeline,
+		toDomain: ToDomainWeatherTimeline,
 	}
+}
 
-	if weather != nil {
-		data := ToDTOWeatherDaily(weather)
-		if err := appRedis.Set(
-			ctx, c.redis, key, data, c.provider.TTL(ForecastDaily),
-		); err != nil {
-			log.Printf("proxy: failed to set cache for key %s: %v\n", key, err)
-		}
+func (c *ProxyClient) currentLoad(
+	city string,
+) *forecastLoad[domain.Weather, *domain.Weather, Weather] {
+	return &forecastLoad[domain.Weather, *domain.Weather, Weather]{
+		proxy:        c,
+		forecastType: ForecastCurrent,
+		key:          c.getForecastKey(ForecastCurrent, city),
+		load: func(ctx context.Context) (*domain.Weather, error) {
+			return c.delegate.GetWeather(ctx, city)
+		},
+		toDTO:    ToDTOWeather,
+		toDomain: ToDomainWeather,
 	}
+}
 
-	return weather, nil
+type stamped[T any] interface {
+	*T
+	MarkFetched(at time.Time)
+	Hints() domain.Freshness
 }
 
-func (c *ProxyClient) GetHourlyForecast(
-	ctx context.Context, city string,
-) (*domain.WeatherHourly, error) {
-	key := c.getForecastKey(ForecastHourly, city)
+// forecastLoad is a single cached read of one forecast: domain values of type
+// P are cached as Entry[D].
+type forecastLoad[T any, P stamped[T], D any] struct {
+	proxy        *ProxyClient
+	forecastType ForecastType
+	key          string
+	load         func(ctx context.Context) (P, error)
+	toDTO        func(P) *D
+	toDomain     func(*D) P
+}
+
+// get answers from a fresh entry, or from an expired one while it is refreshed
+// in the background. Expired entries are not served on errors here, so a
+// failing provider still fails and the chain can try the next one; see
+// StaleFallbackClient.
+func (f *forecastLoad[T, P, D]) get(ctx context.Context) (P, error) {
+	entry, err := f.read(ctx)
+	if value, answered, err := f.fromCache(ctx, entry, err); answered {
+		return value, err
+	}
+	return cache.Load(ctx, f.proxy.coalescer, f.key, f.lookup, f.store)
+}
+
+// fromCache answers from a cached entry when it can and records the outcome.
+func (f *forecastLoad[T, P, D]) fromCache(
+	ctx context.Context, entry *Entry[D], err error,
+) (P, bool, error) {
+	c := f.proxy
+	now := c.clock.Now()
+
+	switch {
+	case err == nil && entry.Rejection != nil:
+		f.record(cache.ResultNegative)
+		return nil, true, entry.Rejection.Err()
+	case err == nil && now.Before(entry.FreshUntil):
+		f.record(cache.ResultHit)
+		return f.fromEntry(entry), true, nil
+	case err == nil && now.Before(entry.FreshUntil.Add(c.staleness.WhileRevalidate)):
+		f.record(cache.ResultStale)
+		go f.refresh(context.WithoutCancel(ctx))
+		return f.fromEntry(entry), true, nil
+	case errors.Is(err, appRedis.ErrUndecodable):
+		f.record(cache.ResultUndecodable)
+	case err == nil || errors.Is(err, cache.ErrMiss):
+		f.record(cache.ResultMiss)
+	}
+	return nil, false, nil
+}
 
-	cached, err := appRedis.Get[WeatherHourly](ctx, c.redis, key)
-	if err == nil {
-		c.recorder.CacheHit()
-		return ToDomainWeatherHourly(cached), nil
+// stale returns the cached forecast once it has expired, for as long as the
+// IfError staleness allows.
+func (f *forecastLoad[T, P, D]) stale(ctx context.Context) (P, bool) {
+	entry, err := f.read(ctx)
+	if err != nil || entry.Value == nil {
+		return nil, false
 	}
-	if errors.Is(err, redis.Nil) {
-		c.recorder.CacheMiss()
+	if !f.proxy.clock.Now().Before(entry.FreshUntil.Add(f.proxy.staleness.IfError)) {
+		return nil, false
 	}
+	f.record(cache.ResultStale)
+	return f.fromEntry(entry), true
+}
 
-	weather, err := c.delegate.GetHourlyForecast(ctx, city)
+func (f *forecastLoad[T, P, D]) read(ctx context.Context) (*Entry[D], error) {
+	entry, err := appRedis.Get[Entry[D]](ctx, f.proxy.cache, f.proxy.codec, f.key)
 	if err != nil {
 		return nil, err
 	}
-
-	if weather != nil {
-		data := ToDTOWeatherHourly(weather)
-		if err := appRedis.Set(
-			ctx, c.redis, key, data, c.provider.TTL(ForecastHourly),
-		); err != nil {
-			log.Printf("proxy: failed to set cache for key %s: %v\n", key, err)
-		}
+	if !isUsable(entry) {
+		return nil, cache.ErrMiss
 	}
+	return entry, nil
+}
 
-	return weather, nil
+func (f *forecastLoad[T, P, D]) refresh(ctx context.Context) {
+	if _, err := cache.Load(ctx, f.proxy.coalescer, f.key, f.lookup, f.store); err != nil {
+		log.Printf("proxy: background refresh of key %s failed: %v\n", f.key, err)
+	}
 }
 
-func (c *ProxyClient) GetWeather(ctx context.Context, city string) (*domain.Weather, error) {
-	key := c.getForecastKey(ForecastCurrent, city)
+// lookup finds a forecast or a rejection stored fresh by another caller in the
+// meantime. A rejection is a final answer, so waiters return it at once.
+func (f *forecastLoad[T, P, D]) lookup(ctx context.Context) (P, bool, error) {
+	entry, err := f.read(ctx)
+	if err != nil || !f.proxy.clock.Now().Before(entry.FreshUntil) {
+		return nil, false, nil
+	}
+	if entry.Rejection != nil {
+		return nil, true, entry.Rejection.Err()
+	}
+	return f.fromEntry(entry), true, nil
+}
 
-	cached, err := appRedis.Get[Weather](ctx, c.redis, key)
-	if err == nil {
-		c.recorder.CacheHit()
-		return ToDomainWeather(cached), nil
+func (f *forecastLoad[T, P, D]) store(ctx context.Context) (P, error) {
+	c := f.proxy
+	value, err := f.load(ctx)
+	if err != nil {
+		f.reject(ctx, err)
+		return value, err
 	}
-	if errors.Is(err, redis.Nil) {
-		c.recorder.CacheMiss()
+	if value == nil {
+		return value, nil
 	}
 
-	weather, err := c.delegate.GetWeather(ctx, city)
+	fetchedAt := c.clock.Now()
+	value.MarkFetched(fetchedAt)
+	ttl := c.provider.TTL(f.forecastType, value.Hints())
+	entry := Entry[D]{Value: f.toDTO(value), FetchedAt: fetchedAt, FreshUntil: fetchedAt.Add(ttl)}
+	err = appRedis.Set(ctx, c.cache, c.codec, f.key, entry, ttl+c.staleness.retention())
 	if err != nil {
-		return nil, err
+		log.Printf("proxy: failed to set cache for key %s: %v\n", f.key, err)
+	}
+	return value, nil
+}
+
+// reject caches the provider's verdict that the city does not exist.
+func (f *forecastLoad[T, P, D]) reject(ctx context.Context, err error) {
+	c := f.proxy
+	if c.negativeTTL <= 0 || internalErrors.Class(err) != internalErrors.ClassNotFound {
+		return
 	}
 
-	if weather != nil {
-		data := ToDTOWeather(weather)
-		if err := appRedis.Set(
-			ctx, c.redis, key, data, c.provider.TTL(ForecastCurrent),
-		); err != nil {
-			log.Printf("proxy: failed to set cache for key %s: %v\n", key, err)
-		}
+	now := c.clock.Now()
+	entry := Entry[D]{
+		Rejection:  appRedis.NewRejection(err),
+		FetchedAt:  now,
+		FreshUntil: now.Add(c.negativeTTL),
 	}
+	if err := appRedis.Set(ctx, c.cache, c.codec, f.key, entry, c.negativeTTL); err != nil {
+		log.Printf("proxy: failed to cache rejection for key %s: %v\n", f.key, err)
+	}
+}
 
-	return weather, nil
+func (f *forecastLoad[T, P, D]) record(result string) {
+	f.proxy.recorder.CacheResult(f.proxy.prefix, string(f.forecastType), result)
+}
+
+func (f *forecastLoad[T, P, D]) fromEntry(entry *Entry[D]) P {
+	value := f.toDomain(entry.Value)
+	value.MarkFetched(entry.FetchedAt)
+	return value
+}
+
+// isUsable rejects entries that carry neither a forecast nor a rejection, or
+// no freshness.
+func isUsable[D any](entry *Entry[D]) bool {
+	return (entry.Value != nil || entry.Rejection != nil) && !entry.FreshUntil.IsZero()
+}
+
+// servesStale reports whether an expired forecast is a better answer than err.
+// Errors about the request itself are returned as they are.
+func servesStale(err error) bool {
+	switch internalErrors.Class(err) {
+	case internalErrors.ClassNotFound, internalErrors.ClassInvalidInput,
+		internalErrors.ClassCanceled:
+		return false
+	default:
+		return true
+	}
 }
 
 func (c *ProxyClient) getForecastKey(forecastType ForecastType, city string) string {
-	return fmt.Sprintf("%s:%s:%s", c.prefix, forecastType, strings.ToLower(city))
+	return appRedis.Key(c.prefix, SchemaVersion, string(forecastType), appRedis.CityKey(city))
 }
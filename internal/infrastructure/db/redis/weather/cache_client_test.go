@@ -0,0 +1,245 @@
+//go:build unit
+// +build unit
+
+package weather
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/infrastructure/db/cache"
+	"weather-api/internal/test/stubs"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+const freshTTL = 10 * time.Minute
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type fixedTTL struct{}
+
+func (fixedTTL) TTL(ForecastType, domain.Freshness) time.Duration {
+	return freshTTL
+}
+
+type metricsStub struct {
+	mu      sync.Mutex
+	results []string
+}
+
+func (m *metricsStub) CacheResult(_, forecastType, result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results = append(m.results, forecastType+":"+result)
+}
+
+func (m *metricsStub) Results() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.results
+}
+
+// heldLocker reports the cache lock as held by another instance.
+type heldLocker struct{}
+
+func (heldLocker) TryLock(context.Context, string, time.Duration) (func(), bool, error) {
+	return nil, false, nil
+}
+
+func newTestProxy(t *testing.T) (*ProxyClient, *stubs.WeatherRepositoryStub, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{now: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)}
+	proxy, delegate := newTestProxyWithStore(cache.NewLocalCache(100, 24*time.Hour, clock, nil), clock)
+	return proxy, delegate, clock
+}
+
+func newTestProxyWithStore(
+	store cache.Cache, clock *fakeClock,
+) (*ProxyClient, *stubs.WeatherRepositoryStub) {
+	delegate := stubs.NewWeatherRepositoryStub()
+	proxy := NewProxyClient(
+		delegate,
+		store,
+		cache.JSONCodec{},
+		cache.NewCoalescer(nil, 0),
+		fixedTTL{},
+		clock,
+		"weather-api",
+		&metricsStub{},
+	)
+	proxy.SetStaleness(Staleness{WhileRevalidate: 5 * time.Minute, IfError: time.Hour})
+	proxy.SetNegativeTTL(time.Minute)
+	return proxy, delegate
+}
+
+func TestProxyClient_ServesFreshEntries(t *testing.T) {
+	proxy, delegate, clock := newTestProxy(t)
+	fetchedAt := clock.Now()
+
+	_, err := proxy.GetWeather(context.Background(), "Kyiv")
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+	weather, err := proxy.GetWeather(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Clear sky", weather.Description)
+	assert.Equal(t, fetchedAt, weather.FetchedAt)
+	assert.Equal(t, 1, delegate.GetCallCount("Kyiv"))
+	assert.Equal(t, []string{"current:miss", "current:hit"}, proxy.recorder.(*metricsStub).Results())
+}
+
+func TestProxyClient_RevalidatesStaleEntriesInBackground(t *testing.T) {
+	proxy, delegate, clock := newTestProxy(t)
+	fetchedAt := clock.Now()
+
+	_, err := proxy.GetWeather(context.Background(), "Kyiv")
+	require.NoError(t, err)
+	clock.Advance(freshTTL + time.Minute)
+	weather, err := proxy.GetWeather(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, fetchedAt, weather.FetchedAt)
+	assert.Eventually(t, func() bool {
+		return delegate.GetCallCount("Kyiv") == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProxyClient_ReturnsProviderErrorsDespiteStaleEntries(t *testing.T) {
+	proxy, delegate, clock := newTestProxy(t)
+
+	_, err := proxy.GetWeather(context.Background(), "Kyiv")
+	require.NoError(t, err)
+	delegate.GetWeatherFn = func(string) (*domain.Weather, error) {
+		return nil, pkgErrors.New(internalErrors.ErrServiceUnavailable, "provider failed")
+	}
+	clock.Advance(freshTTL + 30*time.Minute)
+	_, err = proxy.GetWeather(context.Background(), "Kyiv")
+
+	assert.ErrorIs(t, err, internalErrors.ErrServiceUnavailable)
+	assert.Equal(t, 2, delegate.GetCallCount("Kyiv"))
+}
+
+func TestProxyClient_TreatsUndecodableEntriesAsMisses(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)}
+	store := cache.NewLocalCache(100, 24*time.Hour, clock, nil)
+	proxy, delegate := newTestProxyWithStore(store, clock)
+	key := fmt.Sprintf("weather-api:v%d:current:kyiv", SchemaVersion)
+	require.NoError(t, store.Set(context.Background(), key, []byte{0x93, 0x01}, time.Hour))
+
+	weather, err := proxy.GetWeather(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Clear sky", weather.Description)
+	assert.Equal(t, 1, delegate.GetCallCount("Kyiv"))
+}
+
+func TestProxyClient_CachesUnknownCities(t *testing.T) {
+	proxy, delegate, _ := newTestProxy(t)
+	delegate.GetWeatherFn = func(string) (*domain.Weather, error) {
+		return nil, pkgErrors.New(internalErrors.ErrNotFound, "city not found")
+	}
+
+	_, err := proxy.GetWeather(context.Background(), "Atlantis")
+	require.ErrorIs(t, err, internalErrors.ErrNotFound)
+	_, err = proxy.GetWeather(context.Background(), "Atlantis")
+
+	assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+	assert.Equal(t, "city not found", err.Error())
+	assert.Equal(t, 1, delegate.GetCallCount("Atlantis"))
+}
+
+func TestProxyClient_DoesNotCacheProviderFailures(t *testing.T) {
+	proxy, delegate, _ := newTestProxy(t)
+	delegate.GetWeatherFn = func(string) (*domain.Weather, error) {
+		return nil, pkgErrors.New(internalErrors.ErrServiceUnavailable, "all providers failed")
+	}
+
+	_, err := proxy.GetWeather(context.Background(), "Kyiv")
+	require.Error(t, err)
+	_, err = proxy.GetWeather(context.Background(), "Kyiv")
+
+	assert.ErrorIs(t, err, internalErrors.ErrServiceUnavailable)
+	assert.Equal(t, 2, delegate.GetCallCount("Kyiv"))
+}
+
+func TestProxyClient_ReplacesRejectionOnceCityResolves(t *testing.T) {
+	proxy, delegate, clock := newTestProxy(t)
+	delegate.GetWeatherFn = func(string) (*domain.Weather, error) {
+		return nil, pkgErrors.New(internalErrors.ErrNotFound, "city not found")
+	}
+	_, err := proxy.GetWeather(context.Background(), "Kyiv")
+	require.ErrorIs(t, err, internalErrors.ErrNotFound)
+
+	delegate.GetWeatherFn = nil
+	clock.Advance(2 * time.Minute)
+	_, err = proxy.GetWeather(context.Background(), "Kyiv")
+	require.NoError(t, err)
+	weather, err := proxy.GetWeather(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Clear sky", weather.Description)
+	assert.Equal(t, 2, delegate.GetCallCount("Kyiv"))
+}
+
+func TestProxyClient_WaitersReturnRejectionStoredByLockHolder(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)}
+	store := cache.NewLocalCache(100, 24*time.Hour, clock, nil)
+	holder, holderDelegate := newTestProxyWithStore(store, clock)
+	holderDelegate.GetWeatherFn = func(string) (*domain.Weather, error) {
+		return nil, pkgErrors.New(internalErrors.ErrNotFound, "city not found")
+	}
+	waiter, waiterDelegate := newTestProxyWithStore(store, clock)
+	waiter.coalescer = cache.NewCoalescer(heldLocker{}, time.Minute)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := waiter.GetWeather(context.Background(), "Atlantis")
+		errs <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	_, err := holder.GetWeather(context.Background(), "Atlantis")
+	require.ErrorIs(t, err, internalErrors.ErrNotFound)
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+	case <-time.After(time.Second):
+		t.Fatal("waiter kept waiting despite the cached rejection")
+	}
+	assert.Zero(t, waiterDelegate.GetCallCount("Atlantis"))
+}
+
+func TestProxyClient_NormalizesCityKeys(t *testing.T) {
+	proxy, delegate, _ := newTestProxy(t)
+
+	_, err := proxy.GetWeather(context.Background(), " Kyiv ")
+	require.NoError(t, err)
+	_, err = proxy.GetWeather(context.Background(), "kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, delegate.GetCallCount(" Kyiv "))
+	assert.Zero(t, delegate.GetCallCount("kyiv"))
+}
@@ -94,3 +94,31 @@ func ToDTOWeatherHourly(domain *domain.WeatherHourly) *WeatherHourly {
 		Icon:       domain.Icon,
 	}
 }
+
+func ToDomainWeatherTimeline(dto *WeatherTimeline) *domain.WeatherTimeline {
+	if dto == nil {
+		return nil
+	}
+	hours := make([]domain.WeatherHourly, 0, len(dto.Hours))
+	for _, hour := range dto.Hours {
+		hours = append(hours, *ToDomainWeatherHourly(&hour))
+	}
+	return &domain.WeatherTimeline{
+		Location: dto.Location,
+		Hours:    hours,
+	}
+}
+
+func ToDTOWeatherTimeline(domain *domain.WeatherTimeline) *WeatherTimeline {
+	if domain == nil {
+		return nil
+	}
+	hours := make([]WeatherHourly, 0, len(domain.Hours))
+	for _, hour := range domain.Hours {
+		hours = append(hours, *ToDTOWeatherHourly(&hour))
+	}
+	return &WeatherTimeline{
+		Location: domain.Location,
+		Hours:    hours,
+	}
+}
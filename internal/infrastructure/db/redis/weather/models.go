@@ -1,5 +1,15 @@
 package weather
 
+import (
+	"time"
+
+	appRedis "weather-api/internal/infrastructure/db/redis"
+)
+
+// SchemaVersion is part of every forecast key. Bump it whenever a model below
+// changes, so entries cached under the old shape are no longer read.
+const SchemaVersion = 2
+
 type Weather struct {
 	Temperature float64 `json:"temperature"`
 	Humidity    float64 `json:"humidity"`
@@ -31,3 +41,18 @@ type WeatherHourly struct {
 	Condition  string  `json:"condition"`
 	Icon       string  `json:"icon"`
 }
+
+type WeatherTimeline struct {
+	Location string          `json:"location"`
+	Hours    []WeatherHourly `json:"hours"`
+}
+
+// Entry wraps a cached forecast with the time it was fetched and the time it
+// stops being fresh. The key itself outlives FreshUntil by the stale window.
+// Unknown cities are cached as a Rejection without a value.
+type Entry[T any] struct {
+	Value      *T                  `json:"value"`
+	Rejection  *appRedis.Rejection `json:"rejection,omitempty"`
+	FetchedAt  time.Time           `json:"fetched_at"`
+	FreshUntil time.Time           `json:"fresh_until"`
+}
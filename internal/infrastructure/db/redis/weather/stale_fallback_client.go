@@ -0,0 +1,86 @@
+package weather
+
+import (
+	"context"
+	"log"
+
+	"weather-api/internal/domain"
+)
+
+// StaleFallbackClient wraps the whole provider chain and answers with an
+// expired forecast from any provider's cache once the chain has failed. Doing
+// this above the chain rather than in each ProxyClient keeps a stale entry of
+// one provider from hiding its failure from the chain and from health checks.
+type StaleFallbackClient struct {
+	delegate Client
+	caches   []*ProxyClient
+}
+
+func NewStaleFallbackClient(delegate Client, caches []*ProxyClient) *StaleFallbackClient {
+	return &StaleFallbackClient{delegate: delegate, caches: caches}
+}
+
+func (c *StaleFallbackClient) GetDailyForecast(
+	ctx context.Context, city string,
+) (*domain.WeatherDaily, error) {
+	forecast, err := c.delegate.GetDailyForecast(ctx, city)
+	return fallback(ctx, c.caches, forecast, err,
+		func(ctx context.Context, proxy *ProxyClient) (*domain.WeatherDaily, bool) {
+			return proxy.dailyLoad(city).stale(ctx)
+		},
+	)
+}
+
+func (c *StaleFallbackClient) GetHourlyForecast(
+	ctx context.Context, city string,
+) (*domain.WeatherHourly, error) {
+	forecast, err := c.delegate.GetHourlyForecast(ctx, city)
+	return fallback(ctx, c.caches, forecast, err,
+		func(ctx context.Context, proxy *ProxyClient) (*domain.WeatherHourly, bool) {
+			return proxy.hourlyLoad(city).stale(ctx)
+		},
+	)
+}
+
+func (c *StaleFallbackClient) GetHourlyTimeline(
+	ctx context.Context, city string, hours int,
+) (*domain.WeatherTimeline, error) {
+	timeline, err := c.delegate.GetHourlyTimeline(ctx, city, hours)
+	return fallback(ctx, c.caches, timeline, err,
+		func(ctx context.Context, proxy *ProxyClient) (*domain.WeatherTimeline, bool) {
+			return proxy.timelineLoad(city, hours).stale(ctx)
+		},
+	)
+}
+
+func (c *StaleFallbackClient) GetWeather(
+	ctx context.Context, city string,
+) (*domain.Weather, error) {
+	weather, err := c.delegate.GetWeather(ctx, city)
+	return fallback(ctx, c.caches, weather, err,
+		func(ctx context.Context, proxy *ProxyClient) (*domain.Weather, bool) {
+			return proxy.currentLoad(city).stale(ctx)
+		},
+	)
+}
+
+// fallback returns the first stale forecast found in the caches, in chain
+// order, when err is one an expired forecast is a better answer to.
+func fallback[P any](
+	ctx context.Context,
+	caches []*ProxyClient,
+	value P,
+	err error,
+	stale func(ctx context.Context, proxy *ProxyClient) (P, bool),
+) (P, error) {
+	if err == nil || !servesStale(err) {
+		return value, err
+	}
+	for _, proxy := range caches {
+		if cached, ok := stale(ctx, proxy); ok {
+			log.Printf("proxy: serving stale %s forecast after error: %v\n", proxy.prefix, err)
+			return cached, nil
+		}
+	}
+	return value, err
+}
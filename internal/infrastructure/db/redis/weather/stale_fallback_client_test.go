@@ -0,0 +1,118 @@
+//go:build unit
+// +build unit
+
+package weather
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/infrastructure/chain"
+	"weather-api/internal/infrastructure/db/cache"
+	httpWeather "weather-api/internal/infrastructure/http/weather"
+	"weather-api/internal/test/stubs"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+func unavailable(string) (*domain.Weather, error) {
+	return nil, pkgErrors.New(internalErrors.ErrServiceUnavailable, "provider failed")
+}
+
+func newNamedProxy(
+	store cache.Cache, clock *fakeClock, prefix string,
+) (*ProxyClient, *stubs.WeatherRepositoryStub) {
+	delegate := stubs.NewWeatherRepositoryStub()
+	proxy := NewProxyClient(
+		delegate,
+		store,
+		cache.JSONCodec{},
+		cache.NewCoalescer(nil, 0),
+		fixedTTL{},
+		clock,
+		prefix,
+		&metricsStub{},
+	)
+	proxy.SetStaleness(Staleness{WhileRevalidate: 5 * time.Minute, IfError: time.Hour})
+	return proxy, delegate
+}
+
+func TestStaleFallbackClient_TriesNextProviderBeforeServingStale(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)}
+	store := cache.NewLocalCache(100, 24*time.Hour, clock, nil)
+	first, firstDelegate := newNamedProxy(store, clock, "open-meteo")
+	second, secondDelegate := newNamedProxy(store, clock, "weather-api")
+	links := []chain.Link[httpWeather.Client]{
+		{Name: "open-meteo", Client: first},
+		{Name: "weather-api", Client: second},
+	}
+	client := NewStaleFallbackClient(
+		httpWeather.NewHandler(chain.New("weather", links, chain.FallbackExcept())),
+		[]*ProxyClient{first, second},
+	)
+
+	_, err := client.GetWeather(context.Background(), "Kyiv")
+	require.NoError(t, err)
+	firstDelegate.GetWeatherFn = unavailable
+	secondDelegate.GetWeatherFn = func(string) (*domain.Weather, error) {
+		return &domain.Weather{Temperature: 18, Description: "Partly cloudy"}, nil
+	}
+	clock.Advance(freshTTL + 30*time.Minute)
+	weather, err := client.GetWeather(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Partly cloudy", weather.Description)
+	assert.Equal(t, clock.Now(), weather.FetchedAt)
+	assert.Equal(t, 2, firstDelegate.GetCallCount("Kyiv"))
+	assert.Equal(t, 1, secondDelegate.GetCallCount("Kyiv"))
+}
+
+func TestStaleFallbackClient_ServesStaleWhenAllProvidersFail(t *testing.T) {
+	proxy, delegate, clock := newTestProxy(t)
+	client := NewStaleFallbackClient(proxy, []*ProxyClient{proxy})
+	fetchedAt := clock.Now()
+
+	_, err := client.GetWeather(context.Background(), "Kyiv")
+	require.NoError(t, err)
+	delegate.GetWeatherFn = unavailable
+	clock.Advance(freshTTL + 30*time.Minute)
+	weather, err := client.GetWeather(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, fetchedAt, weather.FetchedAt)
+	assert.Equal(t, 2, delegate.GetCallCount("Kyiv"))
+	assert.Contains(t, proxy.recorder.(*metricsStub).Results(), "current:stale")
+}
+
+func TestStaleFallbackClient_ReturnsErrorsBeyondStaleLimit(t *testing.T) {
+	proxy, delegate, clock := newTestProxy(t)
+	client := NewStaleFallbackClient(proxy, []*ProxyClient{proxy})
+
+	_, err := client.GetWeather(context.Background(), "Kyiv")
+	require.NoError(t, err)
+	delegate.GetWeatherFn = unavailable
+	clock.Advance(freshTTL + 2*time.Hour)
+	_, err = client.GetWeather(context.Background(), "Kyiv")
+
+	assert.ErrorIs(t, err, internalErrors.ErrServiceUnavailable)
+}
+
+func TestStaleFallbackClient_DoesNotServeStaleForUnknownCity(t *testing.T) {
+	proxy, delegate, clock := newTestProxy(t)
+	client := NewStaleFallbackClient(proxy, []*ProxyClient{proxy})
+
+	_, err := client.GetWeather(context.Background(), "Kyiv")
+	require.NoError(t, err)
+	delegate.GetWeatherFn = func(string) (*domain.Weather, error) {
+		return nil, pkgErrors.New(internalErrors.ErrNotFound, "city not found")
+	}
+	clock.Advance(freshTTL + 30*time.Minute)
+	_, err = client.GetWeather(context.Background(), "Kyiv")
+
+	assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+}
@@ -1,8 +1,10 @@
 package ttl
 
 import (
+	"sync"
 	"time"
 
+	"weather-api/internal/domain"
 	"weather-api/internal/infrastructure/db/redis/weather"
 )
 
@@ -10,9 +12,17 @@ type Clock interface {
 	Now() time.Time
 }
 
+// Provider expires hourly data at the end of the hour and daily data at
+// midnight, both in the timezone of the forecast location. Locations without
+// a known timezone use the clock's.
+//
+// A provider's ValidUntil hint replaces the configured TTL of current
+// weather, and shortens but never extends the others: a forecast for today
+// is not valid past the end of today, whatever the upstream says.
 type Provider struct {
 	clock      Clock
 	currentTTL time.Duration
+	zones      sync.Map
 }
 
 func NewTTLProvider(currentTTL time.Duration, clock Clock) *Provider {
@@ -22,21 +32,51 @@ func NewTTLProvider(currentTTL time.Duration, clock Clock) *Provider {
 	}
 }
 
-func (p *Provider) TTL(forecastType weather.ForecastType) time.Duration {
+func (p *Provider) TTL(forecastType weather.ForecastType, hints domain.Freshness) time.Duration {
+	now := p.clock.Now()
+	var hinted time.Duration
+	if hints.ValidUntil.After(now) {
+		hinted = hints.ValidUntil.Sub(now)
+	}
+
+	local := now.In(p.location(hints.Timezone, now))
+	var boundary time.Time
 	switch forecastType {
 	case weather.ForecastCurrent:
+		if hinted > 0 {
+			return hinted
+		}
 		return p.currentTTL
-	case weather.ForecastHourly:
-		endOfHour := p.clock.Now().Truncate(time.Hour).Add(time.Hour)
-		return time.Until(endOfHour)
-	case weather.ForecastDaily:
-		tomorrow := p.clock.Now().AddDate(0, 0, 1)
-		midnight := time.Date(
-			tomorrow.Year(), tomorrow.Month(), tomorrow.Day(),
-			0, 0, 0, 0, p.clock.Now().Location(),
+	case weather.ForecastHourly, weather.ForecastTimeline:
+		boundary = time.Date(
+			local.Year(), local.Month(), local.Day(), local.Hour()+1, 0, 0, 0, local.Location(),
 		)
-		return time.Until(midnight)
+	case weather.ForecastDaily:
+		boundary = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, local.Location())
 	default:
 		return 1 * time.Minute
 	}
+
+	ttl := boundary.Sub(now)
+	if hinted > 0 && hinted < ttl {
+		return hinted
+	}
+	return ttl
+}
+
+// location resolves an IANA timezone name, falling back to the clock's own
+// location when the name is empty or unknown.
+func (p *Provider) location(timezone string, now time.Time) *time.Location {
+	if timezone == "" {
+		return now.Location()
+	}
+	if cached, ok := p.zones.Load(timezone); ok {
+		return cached.(*time.Location)
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = now.Location()
+	}
+	p.zones.Store(timezone, location)
+	return location
 }
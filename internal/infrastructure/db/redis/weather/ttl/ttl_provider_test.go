@@ -0,0 +1,98 @@
+//go:build unit
+// +build unit
+
+package ttl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"weather-api/internal/domain"
+	"weather-api/internal/infrastructure/db/redis/weather"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+// 2025-06-26 12:20 UTC is 21:20 in Tokyo and 17:50 in Kolkata.
+var now = time.Date(2025, 6, 26, 12, 20, 0, 0, time.UTC)
+
+func TestProvider_TTL(t *testing.T) {
+	tests := []struct {
+		name         string
+		forecastType weather.ForecastType
+		hints        domain.Freshness
+		want         time.Duration
+	}{
+		{
+			name:         "current uses configured TTL",
+			forecastType: weather.ForecastCurrent,
+			want:         15 * time.Minute,
+		},
+		{
+			name:         "current follows upstream hint",
+			forecastType: weather.ForecastCurrent,
+			hints:        domain.Freshness{ValidUntil: now.Add(25 * time.Minute)},
+			want:         25 * time.Minute,
+		},
+		{
+			name:         "past hint is ignored",
+			forecastType: weather.ForecastCurrent,
+			hints:        domain.Freshness{ValidUntil: now.Add(-time.Minute)},
+			want:         15 * time.Minute,
+		},
+		{
+			name:         "daily without timezone expires at clock midnight",
+			forecastType: weather.ForecastDaily,
+			want:         11*time.Hour + 40*time.Minute,
+		},
+		{
+			name:         "daily expires at city midnight",
+			forecastType: weather.ForecastDaily,
+			hints:        domain.Freshness{Timezone: "Asia/Tokyo"},
+			want:         2*time.Hour + 40*time.Minute,
+		},
+		{
+			name:         "unknown timezone falls back to clock",
+			forecastType: weather.ForecastDaily,
+			hints:        domain.Freshness{Timezone: "Mars/Olympus_Mons"},
+			want:         11*time.Hour + 40*time.Minute,
+		},
+		{
+			name:         "hourly follows half-hour offsets",
+			forecastType: weather.ForecastHourly,
+			hints:        domain.Freshness{Timezone: "Asia/Kolkata"},
+			want:         10 * time.Minute,
+		},
+		{
+			name:         "hint shortens timeline",
+			forecastType: weather.ForecastTimeline,
+			hints:        domain.Freshness{ValidUntil: now.Add(5 * time.Minute)},
+			want:         5 * time.Minute,
+		},
+		{
+			name:         "hint does not extend daily past midnight",
+			forecastType: weather.ForecastDaily,
+			hints: domain.Freshness{
+				Timezone:   "Asia/Tokyo",
+				ValidUntil: now.Add(6 * time.Hour),
+			},
+			want: 2*time.Hour + 40*time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewTTLProvider(15*time.Minute, fixedClock{now: now})
+
+			assert.Equal(t, tt.want, provider.TTL(tt.forecastType, tt.hints))
+		})
+	}
+}
@@ -46,6 +46,10 @@ func (s *Sender) WeatherHourlyEmail(email *email.WeatherHourlyEmail) error {
 	return s.sendEmail("templates/hourly.html", email.To, "Your weather hourly forecast", email)
 }
 
+func (s *Sender) ManageLinkEmail(email *email.ManageLinkEmail) error {
+	return s.sendEmail("templates/manage_link.html", email.To, "Manage your subscriptions", email)
+}
+
 func (s *Sender) sendEmail(templatePath, to, subject string, data any) error {
 	htmlBody, err := renderTemplate(templatePath, data)
 	if err != nil {
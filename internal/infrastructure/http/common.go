@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"time"
 
 	internalErrors "weather-api/internal/errors"
 	pkgErrors "weather-api/pkg/errors"
@@ -18,12 +19,22 @@ func Get(ctx context.Context, client *http.Client, endpoint string) (*http.Respo
 	}
 
 	resp, err := client.Do(req)
+	if ctx.Err() != nil {
+		return nil, pkgErrors.New(ctx.Err(), "request to API cancelled")
+	}
 	if err != nil {
 		return nil, pkgErrors.New(internalErrors.ErrInternal, "failed to connect to API")
 	}
 	return resp, nil
 }
 
+func TimeoutOrDefault(timeout, fallback time.Duration) time.Duration {
+	if timeout <= 0 {
+		return fallback
+	}
+	return timeout
+}
+
 func MockHTTPClient(response MockResponse) *http.Client {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(response.StatusCode)
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidUntil returns the time until which a response may be reused according
+// to its Cache-Control max-age (less any Age) or, failing that, its Expires
+// header. It returns the zero time when the response gives no usable hint,
+// including when it forbids caching.
+func ValidUntil(header http.Header, now time.Time) time.Time {
+	if header == nil {
+		return time.Time{}
+	}
+	if maxAge, ok := maxAge(header.Get("Cache-Control")); ok {
+		if maxAge <= 0 {
+			return time.Time{}
+		}
+		age, _ := strconv.Atoi(header.Get("Age"))
+		return now.Add(time.Duration(maxAge-max(age, 0)) * time.Second)
+	}
+
+	expires, err := http.ParseTime(header.Get("Expires"))
+	if err != nil || !expires.After(now) {
+		return time.Time{}
+	}
+	return expires
+}
+
+// maxAge reads max-age from a Cache-Control header. no-store and no-cache
+// report a max-age of zero.
+func maxAge(cacheControl string) (int, bool) {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache":
+			return 0, true
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err != nil {
+				return 0, false
+			}
+			return seconds, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,70 @@
+//go:build unit
+// +build unit
+
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidUntil(t *testing.T) {
+	now := time.Date(2025, 6, 26, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Time
+	}{
+		{name: "no hint"},
+		{
+			name:    "max-age",
+			headers: map[string]string{"Cache-Control": "public, max-age=600"},
+			want:    now.Add(10 * time.Minute),
+		},
+		{
+			name:    "max-age less age",
+			headers: map[string]string{"Cache-Control": "max-age=600", "Age": "120"},
+			want:    now.Add(8 * time.Minute),
+		},
+		{
+			name: "max-age wins over expires",
+			headers: map[string]string{
+				"Cache-Control": "max-age=60",
+				"Expires":       now.Add(time.Hour).Format(http.TimeFormat),
+			},
+			want: now.Add(time.Minute),
+		},
+		{
+			name:    "expires",
+			headers: map[string]string{"Expires": now.Add(time.Hour).Format(http.TimeFormat)},
+			want:    now.Add(time.Hour),
+		},
+		{
+			name:    "expired",
+			headers: map[string]string{"Expires": now.Add(-time.Hour).Format(http.TimeFormat)},
+		},
+		{
+			name:    "no-cache",
+			headers: map[string]string{"Cache-Control": "no-cache, max-age=600"},
+		},
+		{
+			name:    "malformed",
+			headers: map[string]string{"Cache-Control": "max-age=soon", "Expires": "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for name, value := range tt.headers {
+				header.Set(name, value)
+			}
+
+			assert.True(t, tt.want.Equal(ValidUntil(header, now)))
+		})
+	}
+}
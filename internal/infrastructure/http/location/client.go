@@ -0,0 +1,16 @@
+package location
+
+import (
+	"context"
+	"strings"
+
+	"weather-api/internal/domain"
+)
+
+type Client interface {
+	Resolve(ctx context.Context, city string) (*domain.Location, error)
+}
+
+func NormalizeQuery(city string) string {
+	return strings.ToLower(strings.TrimSpace(city))
+}
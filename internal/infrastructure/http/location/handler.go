@@ -0,0 +1,23 @@
+package location
+
+import (
+	"context"
+
+	"weather-api/internal/domain"
+	"weather-api/internal/infrastructure/chain"
+)
+
+type Handler struct {
+	chain *chain.Chain[Client]
+}
+
+func NewHandler(clients *chain.Chain[Client]) *Handler {
+	return &Handler{chain: clients}
+}
+
+func (h *Handler) Resolve(ctx context.Context, city string) (*domain.Location, error) {
+	return chain.Execute(ctx, h.chain,
+		func(ctx context.Context, client Client) (*domain.Location, error) {
+			return client.Resolve(ctx, city)
+		})
+}
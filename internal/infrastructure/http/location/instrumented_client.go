@@ -0,0 +1,34 @@
+package location
+
+import (
+	"context"
+	"time"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+)
+
+type RequestRecorder interface {
+	ObserveRequest(provider, operation, outcome string, latency time.Duration)
+}
+
+type InstrumentedClient struct {
+	delegate Client
+	provider string
+	recorder RequestRecorder
+}
+
+func NewInstrumentedClient(
+	delegate Client, provider string, recorder RequestRecorder,
+) *InstrumentedClient {
+	return &InstrumentedClient{delegate: delegate, provider: provider, recorder: recorder}
+}
+
+func (c *InstrumentedClient) Resolve(ctx context.Context, city string) (*domain.Location, error) {
+	start := time.Now()
+	location, err := c.delegate.Resolve(ctx, city)
+	c.recorder.ObserveRequest(
+		c.provider, "resolve", internalErrors.Class(err), time.Since(start),
+	)
+	return location, err
+}
@@ -0,0 +1,46 @@
+package location
+
+import (
+	"context"
+	"log"
+
+	"weather-api/internal/domain"
+)
+
+type Store interface {
+	FindByQuery(ctx context.Context, query string) (*domain.Location, error)
+	Save(ctx context.Context, query string, location *domain.Location) error
+}
+
+// PersistentClient resolves every city through the delegate only once and
+// keeps the result in the store for all later lookups.
+type PersistentClient struct {
+	delegate Client
+	store    Store
+}
+
+func NewPersistentClient(delegate Client, store Store) *PersistentClient {
+	return &PersistentClient{delegate: delegate, store: store}
+}
+
+func (c *PersistentClient) Resolve(ctx context.Context, city string) (*domain.Location, error) {
+	query := NormalizeQuery(city)
+
+	stored, err := c.store.FindByQuery(ctx, query)
+	if err != nil {
+		log.Printf("location: failed to load %q from store: %v\n", query, err)
+	}
+	if stored != nil {
+		return stored, nil
+	}
+
+	resolved, err := c.delegate.Resolve(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.store.Save(ctx, query, resolved); err != nil {
+		log.Printf("location: failed to store %q: %v\n", query, err)
+	}
+	return resolved, nil
+}
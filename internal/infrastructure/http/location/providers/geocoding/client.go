@@ -0,0 +1,100 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	appHttp "weather-api/internal/infrastructure/http"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+type Logger interface {
+	LogResponse(provider string, resp *http.Response)
+}
+
+type Client struct {
+	geoCodingUrl string
+	client       *http.Client
+	logger       Logger
+	retry        *appHttp.RetryPolicy
+}
+
+const (
+	providerName   = "geocoding"
+	defaultTimeout = 10 * time.Second
+)
+
+func NewClient(
+	geoCodingUrl string,
+	logger Logger,
+	timeout time.Duration,
+	retry *appHttp.RetryPolicy,
+) *Client {
+	client := &http.Client{
+		Timeout: appHttp.TimeoutOrDefault(timeout, defaultTimeout),
+	}
+	return &Client{client: client, geoCodingUrl: geoCodingUrl, logger: logger, retry: retry}
+}
+
+type LocationResponse struct {
+	Results []struct {
+		Name      string  `json:"name"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Country   string  `json:"country"`
+		Timezone  string  `json:"timezone"`
+	} `json:"results"`
+}
+
+func (h *Client) Resolve(ctx context.Context, city string) (*domain.Location, error) {
+	endpoint := fmt.Sprintf("%s/search?name=%s&count=1", h.geoCodingUrl, url.QueryEscape(city))
+
+	resp, err := h.retry.Get(ctx, h.client, endpoint)
+	switch internalErrors.Class(err) {
+	case internalErrors.ClassQuotaExceeded, internalErrors.ClassCanceled:
+		return nil, err
+	}
+	if err != nil {
+		return nil, pkgErrors.New(
+			internalErrors.ErrServiceUnavailable, "failed to connect to geo-coding API",
+		)
+	}
+	h.logger.LogResponse(providerName, resp)
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Println("Error closing response body:", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, pkgErrors.New(
+			internalErrors.ErrServiceUnavailable, "unexpected error from geo-coding API",
+		)
+	}
+
+	var apiResponse LocationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, pkgErrors.New(
+			internalErrors.ErrInternal, "failed to parse geolocation response",
+		)
+	}
+	if len(apiResponse.Results) == 0 {
+		return nil, pkgErrors.New(internalErrors.ErrNotFound, "city not found")
+	}
+
+	result := apiResponse.Results[0]
+	return &domain.Location{
+		Name:      result.Name,
+		Latitude:  result.Latitude,
+		Longitude: result.Longitude,
+		Country:   result.Country,
+		Timezone:  result.Timezone,
+	}, nil
+}
@@ -0,0 +1,53 @@
+//go:build unit
+// +build unit
+
+package geocoding
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalErrors "weather-api/internal/errors"
+	appHttp "weather-api/internal/infrastructure/http"
+)
+
+const geoCodingURL = "http://geocoding-mock/v1"
+
+func TestResolve(t *testing.T) {
+	path := filepath.Join("testdata", "location_response.json")
+	content, err := os.ReadFile(path) // #nosec G304 -- filename is controlled and safe
+	require.NoError(t, err)
+
+	client := NewClient(geoCodingURL, appHttp.NoOpLogger{}, 0, nil)
+	client.client = appHttp.MockHTTPClient(appHttp.MockResponse{
+		Body:       string(content),
+		StatusCode: http.StatusOK,
+	})
+
+	location, err := client.Resolve(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Kyiv", location.Name)
+	assert.Equal(t, 50.45466, location.Latitude)
+	assert.Equal(t, 30.5238, location.Longitude)
+	assert.Equal(t, "Ukraine", location.Country)
+	assert.Equal(t, "Europe/Kyiv", location.Timezone)
+}
+
+func TestResolve_NotFound(t *testing.T) {
+	client := NewClient(geoCodingURL, appHttp.NoOpLogger{}, 0, nil)
+	client.client = appHttp.MockHTTPClient(appHttp.MockResponse{
+		Body:       `{"generationtime_ms": 0.1}`,
+		StatusCode: http.StatusOK,
+	})
+
+	_, err := client.Resolve(context.Background(), "Atlantis")
+
+	assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+const (
+	reasonConnection      = "connection"
+	reasonTooManyRequests = "too_many_requests"
+	reasonServerError     = "server_error"
+)
+
+type RetryRecorder interface {
+	RetryAttempt(provider, reason string)
+}
+
+type Quota interface {
+	Allow(ctx context.Context) bool
+}
+
+// RetryPolicy retries idempotent GET requests on connection errors, 429 and 5xx
+// responses using exponential backoff with full jitter. A nil policy performs
+// a single attempt. With a quota, every attempt is charged against it and
+// retries stop once it is exhausted.
+type RetryPolicy struct {
+	provider    string
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	recorder    RetryRecorder
+	quota       Quota
+}
+
+func NewRetryPolicy(
+	provider string,
+	maxAttempts int,
+	baseDelay, maxDelay time.Duration,
+	recorder RetryRecorder,
+) *RetryPolicy {
+	return &RetryPolicy{
+		provider:    provider,
+		maxAttempts: max(maxAttempts, 1),
+		baseDelay:   baseDelay,
+		maxDelay:    maxDelay,
+		recorder:    recorder,
+	}
+}
+
+func (p *RetryPolicy) SetQuota(quota Quota) {
+	p.quota = quota
+}
+
+func (p *RetryPolicy) Get(
+	ctx context.Context, client *http.Client, endpoint string,
+) (*http.Response, error) {
+	if p == nil {
+		return Get(ctx, client, endpoint)
+	}
+	if !p.allow(ctx) {
+		return nil, pkgErrors.New(
+			internalErrors.ErrQuotaExceeded, p.provider+" provider quota exhausted",
+		)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := Get(ctx, client, endpoint)
+
+		reason, retryable := p.classify(ctx, resp, err)
+		if !retryable || attempt >= p.maxAttempts {
+			return resp, err
+		}
+
+		delay, ok := p.delay(ctx, attempt, resp)
+		if !ok || !p.allow(ctx) {
+			return resp, err
+		}
+
+		discard(resp)
+		if p.recorder != nil {
+			p.recorder.RetryAttempt(p.provider, reason)
+		}
+
+		if err := wait(ctx, delay); err != nil {
+			return nil, pkgErrors.New(err, "request cancelled while retrying")
+		}
+	}
+}
+
+func (p *RetryPolicy) allow(ctx context.Context) bool {
+	return p.quota == nil || p.quota.Allow(ctx)
+}
+
+func (p *RetryPolicy) classify(
+	ctx context.Context, resp *http.Response, err error,
+) (string, bool) {
+	if ctx.Err() != nil {
+		return "", false
+	}
+
+	if err != nil {
+		return reasonConnection, true
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return reasonTooManyRequests, true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return reasonServerError, true
+	default:
+		return "", false
+	}
+}
+
+func (p *RetryPolicy) delay(
+	ctx context.Context, attempt int, resp *http.Response,
+) (time.Duration, bool) {
+	delay, found := retryAfter(resp)
+	if found {
+		if delay > p.maxDelay {
+			return 0, false
+		}
+	} else {
+		delay = p.backoff(attempt)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+		return 0, false
+	}
+
+	return delay, true
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.maxDelay
+	if shift := attempt - 1; shift < 32 {
+		if exp := p.baseDelay << shift; exp > 0 && exp < ceiling {
+			ceiling = exp
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	// #nosec G404 -- jitter does not need a cryptographically secure source
+	return time.Duration(rand.Int64N(int64(ceiling) + 1))
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func discard(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,194 @@
+//go:build unit
+// +build unit
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalErrors "weather-api/internal/errors"
+)
+
+type retryRecorderStub struct {
+	reasons []string
+}
+
+func (r *retryRecorderStub) RetryAttempt(_ string, reason string) {
+	r.reasons = append(r.reasons, reason)
+}
+
+func newSequenceServer(
+	t *testing.T, statuses []int, headers map[string]string,
+) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		index := atomic.AddInt32(&calls, 1) - 1
+		for key, value := range headers {
+			w.Header().Set(key, value)
+		}
+		w.WriteHeader(statuses[min(int(index), len(statuses)-1)])
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetryPolicy_RetriesServerErrors(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{
+		http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK,
+	}, nil)
+	recorder := &retryRecorderStub{}
+	policy := NewRetryPolicy("test", 3, time.Millisecond, 5*time.Millisecond, recorder)
+
+	resp, err := policy.Get(context.Background(), server.Client(), server.URL)
+
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	assert.Equal(t, []string{reasonServerError, reasonTooManyRequests}, recorder.reasons)
+}
+
+func TestRetryPolicy_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{http.StatusBadRequest}, nil)
+	policy := NewRetryPolicy("test", 3, time.Millisecond, 5*time.Millisecond, nil)
+
+	resp, err := policy.Get(context.Background(), server.Client(), server.URL)
+
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryPolicy_ReturnsLastResponseWhenAttemptsExhausted(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{http.StatusBadGateway}, nil)
+	policy := NewRetryPolicy("test", 2, time.Millisecond, 5*time.Millisecond, nil)
+
+	resp, err := policy.Get(context.Background(), server.Client(), server.URL)
+
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestRetryPolicy_GivesUpWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	server, calls := newSequenceServer(t,
+		[]int{http.StatusTooManyRequests, http.StatusOK},
+		map[string]string{"Retry-After": "120"},
+	)
+	policy := NewRetryPolicy("test", 3, time.Millisecond, time.Second, nil)
+
+	resp, err := policy.Get(context.Background(), server.Client(), server.URL)
+
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryPolicy_StopsWithinContextDeadline(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{http.StatusServiceUnavailable}, nil)
+	policy := NewRetryPolicy("test", 5, time.Second, 2*time.Second, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := policy.Get(ctx, server.Client(), server.URL)
+
+	assert.Less(t, time.Since(start), time.Second)
+	if err == nil {
+		resp.Body.Close()
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(calls), int32(2))
+}
+
+func TestRetryPolicy_CancelledWhileWaitingIsCanceled(t *testing.T) {
+	server, calls := newSequenceServer(
+		t, []int{http.StatusServiceUnavailable}, map[string]string{"Retry-After": "1"},
+	)
+	policy := NewRetryPolicy("test", 3, time.Millisecond, 2*time.Second, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := policy.Get(ctx, server.Client(), server.URL)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, internalErrors.ClassCanceled, internalErrors.Class(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryPolicy_NilPolicyMakesSingleAttempt(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{http.StatusServiceUnavailable}, nil)
+	var policy *RetryPolicy
+
+	resp, err := policy.Get(context.Background(), server.Client(), server.URL)
+
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+type quotaStub struct {
+	remaining int
+	charges   int
+}
+
+func (q *quotaStub) Allow(context.Context) bool {
+	q.charges++
+	if q.remaining == 0 {
+		return false
+	}
+	q.remaining--
+	return true
+}
+
+func TestRetryPolicy_ChargesQuotaPerAttempt(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{
+		http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK,
+	}, nil)
+	quota := &quotaStub{remaining: 10}
+	policy := NewRetryPolicy("test", 3, time.Millisecond, 5*time.Millisecond, nil)
+	policy.SetQuota(quota)
+
+	resp, err := policy.Get(context.Background(), server.Client(), server.URL)
+
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	assert.Equal(t, 3, quota.charges)
+}
+
+func TestRetryPolicy_StopsRetryingWhenQuotaIsExhausted(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{http.StatusServiceUnavailable}, nil)
+	policy := NewRetryPolicy("test", 3, time.Millisecond, 5*time.Millisecond, nil)
+	policy.SetQuota(&quotaStub{remaining: 2})
+
+	resp, err := policy.Get(context.Background(), server.Client(), server.URL)
+
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestRetryPolicy_RejectsWhenQuotaIsExhausted(t *testing.T) {
+	server, calls := newSequenceServer(t, []int{http.StatusOK}, nil)
+	policy := NewRetryPolicy("test", 3, time.Millisecond, 5*time.Millisecond, nil)
+	policy.SetQuota(&quotaStub{})
+
+	resp, err := policy.Get(context.Background(), server.Client(), server.URL)
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, internalErrors.ErrQuotaExceeded)
+	assert.Equal(t, int32(0), atomic.LoadInt32(calls))
+}
@@ -1,24 +1,21 @@
 package validator
 
-import "context"
+import (
+	"context"
 
-type Handler struct {
-	client Client
-	next   Client
-}
+	"weather-api/internal/infrastructure/chain"
+)
 
-func NewHandler(client Client) *Handler {
-	return &Handler{client: client}
+type Handler struct {
+	chain *chain.Chain[Client]
 }
 
-func (h *Handler) SetNext(next Client) {
-	h.next = next
+func NewHandler(clients *chain.Chain[Client]) *Handler {
+	return &Handler{chain: clients}
 }
 
 func (h *Handler) Validate(ctx context.Context, city string) (*string, error) {
-	resp, err := h.client.Validate(ctx, city)
-	if err != nil && h.next != nil {
-		return h.next.Validate(ctx, city)
-	}
-	return resp, err
+	return chain.Execute(ctx, h.chain, func(ctx context.Context, client Client) (*string, error) {
+		return client.Validate(ctx, city)
+	})
 }
@@ -0,0 +1,33 @@
+package validator
+
+import (
+	"context"
+	"time"
+
+	internalErrors "weather-api/internal/errors"
+)
+
+type RequestRecorder interface {
+	ObserveRequest(provider, operation, outcome string, latency time.Duration)
+}
+
+type InstrumentedClient struct {
+	delegate Client
+	provider string
+	recorder RequestRecorder
+}
+
+func NewInstrumentedClient(
+	delegate Client, provider string, recorder RequestRecorder,
+) *InstrumentedClient {
+	return &InstrumentedClient{delegate: delegate, provider: provider, recorder: recorder}
+}
+
+func (c *InstrumentedClient) Validate(ctx context.Context, city string) (*string, error) {
+	start := time.Now()
+	validated, err := c.delegate.Validate(ctx, city)
+	c.recorder.ObserveRequest(
+		c.provider, "validate", internalErrors.Class(err), time.Since(start),
+	)
+	return validated, err
+}
@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/infrastructure/http/location"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+// LocationClient validates cities through the shared location resolver, so a
+// city known from the locations table or its cache is validated without
+// asking a search provider again.
+type LocationClient struct {
+	resolver location.Client
+}
+
+func NewLocationClient(resolver location.Client) *LocationClient {
+	return &LocationClient{resolver: resolver}
+}
+
+func (c *LocationClient) Validate(ctx context.Context, city string) (*string, error) {
+	resolved, err := c.resolver.Resolve(ctx, city)
+	if errors.Is(err, internalErrors.ErrNotFound) {
+		return nil, errInvalidCity()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(resolved.Name, strings.TrimSpace(city)) {
+		return nil, errInvalidCity()
+	}
+
+	return &resolved.Name, nil
+}
+
+func errInvalidCity() error {
+	return pkgErrors.New(internalErrors.ErrInvalidInput, "Invalid city input")
+}
@@ -0,0 +1,63 @@
+//go:build unit
+// +build unit
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+type resolverStub struct {
+	location *domain.Location
+	err      error
+	queries  []string
+}
+
+func (r *resolverStub) Resolve(_ context.Context, city string) (*domain.Location, error) {
+	r.queries = append(r.queries, city)
+	return r.location, r.err
+}
+
+func TestLocationClient_ReturnsResolvedName(t *testing.T) {
+	resolver := &resolverStub{location: &domain.Location{Name: "Kyiv", Timezone: "Europe/Kyiv"}}
+
+	city, err := NewLocationClient(resolver).Validate(context.Background(), "kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Kyiv", *city)
+	assert.Equal(t, []string{"kyiv"}, resolver.queries)
+}
+
+func TestLocationClient_RejectsUnknownCity(t *testing.T) {
+	resolver := &resolverStub{err: pkgErrors.New(internalErrors.ErrNotFound, "city not found")}
+
+	_, err := NewLocationClient(resolver).Validate(context.Background(), "Atlantis")
+
+	assert.ErrorIs(t, err, internalErrors.ErrInvalidInput)
+}
+
+func TestLocationClient_RejectsDifferentName(t *testing.T) {
+	resolver := &resolverStub{location: &domain.Location{Name: "Kyiv"}}
+
+	_, err := NewLocationClient(resolver).Validate(context.Background(), "Kyi")
+
+	assert.ErrorIs(t, err, internalErrors.ErrInvalidInput)
+}
+
+func TestLocationClient_PassesProviderErrors(t *testing.T) {
+	resolver := &resolverStub{
+		err: pkgErrors.New(internalErrors.ErrServiceUnavailable, "failed to connect"),
+	}
+
+	_, err := NewLocationClient(resolver).Validate(context.Background(), "Kyiv")
+
+	assert.ErrorIs(t, err, internalErrors.ErrServiceUnavailable)
+}
@@ -24,13 +24,19 @@ type Client struct {
 	apiKey string
 	client *http.Client
 	logger Logger
+	retry  *appHttp.RetryPolicy
 }
 
-func NewClient(apiUrl, apiKey string, logger Logger) *Client {
+func NewClient(
+	apiUrl, apiKey string,
+	logger Logger,
+	timeout time.Duration,
+	retry *appHttp.RetryPolicy,
+) *Client {
 	client := &http.Client{
-		Timeout: defaultTimeout,
+		Timeout: appHttp.TimeoutOrDefault(timeout, defaultTimeout),
 	}
-	return &Client{apiUrl: apiUrl, apiKey: apiKey, client: client, logger: logger}
+	return &Client{apiUrl: apiUrl, apiKey: apiKey, client: client, logger: logger, retry: retry}
 }
 
 const (
@@ -46,7 +52,7 @@ func (h *Client) Validate(ctx context.Context, city string) (*string, error) {
 		h.apiKey,
 		url.QueryEscape(city),
 	)
-	resp, err := appHttp.Get(ctx, h.client, endpoint)
+	resp, err := h.retry.Get(ctx, h.client, endpoint)
 	if err != nil {
 		return nil, err
 	}
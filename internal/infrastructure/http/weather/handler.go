@@ -4,44 +4,48 @@ import (
 	"context"
 
 	"weather-api/internal/domain"
+	"weather-api/internal/infrastructure/chain"
 )
 
 type Handler struct {
-	client Client
-	next   Client
+	chain *chain.Chain[Client]
 }
 
-func NewHandler(client Client) *Handler {
-	return &Handler{client: client}
-}
-
-func (h *Handler) SetNext(next Client) {
-	h.next = next
+func NewHandler(clients *chain.Chain[Client]) *Handler {
+	return &Handler{chain: clients}
 }
 
 func (h *Handler) GetWeather(ctx context.Context, city string) (*domain.Weather, error) {
-	resp, err := h.client.GetWeather(ctx, city)
-	if err != nil && h.next != nil {
-		return h.next.GetWeather(ctx, city)
-	}
-	return resp, err
+	return chain.Execute(ctx, h.chain,
+		func(ctx context.Context, client Client) (*domain.Weather, error) {
+			return client.GetWeather(ctx, city)
+		})
 }
 
 func (h *Handler) GetDailyForecast(ctx context.Context, city string) (*domain.WeatherDaily, error) {
-	resp, err := h.client.GetDailyForecast(ctx, city)
-	if err != nil && h.next != nil {
-		return h.next.GetDailyForecast(ctx, city)
-	}
-	return resp, err
+	return chain.Execute(ctx, h.chain,
+		func(ctx context.Context, client Client) (*domain.WeatherDaily, error) {
+			return client.GetDailyForecast(ctx, city)
+		})
 }
 
 func (h *Handler) GetHourlyForecast(
 	ctx context.Context,
 	city string,
 ) (*domain.WeatherHourly, error) {
-	resp, err := h.client.GetHourlyForecast(ctx, city)
-	if err != nil && h.next != nil {
-		return h.next.GetHourlyForecast(ctx, city)
-	}
-	return resp, err
+	return chain.Execute(ctx, h.chain,
+		func(ctx context.Context, client Client) (*domain.WeatherHourly, error) {
+			return client.GetHourlyForecast(ctx, city)
+		})
+}
+
+func (h *Handler) GetHourlyTimeline(
+	ctx context.Context,
+	city string,
+	hours int,
+) (*domain.WeatherTimeline, error) {
+	return chain.Execute(ctx, h.chain,
+		func(ctx context.Context, client Client) (*domain.WeatherTimeline, error) {
+			return client.GetHourlyTimeline(ctx, city, hours)
+		})
 }
@@ -0,0 +1,82 @@
+//go:build unit
+// +build unit
+
+package weather
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/infrastructure/chain"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+type delayedClient struct {
+	delay     time.Duration
+	weather   *domain.Weather
+	err       error
+	calls     atomic.Int32
+	cancelled atomic.Bool
+}
+
+func (c *delayedClient) GetWeather(ctx context.Context, _ string) (*domain.Weather, error) {
+	c.calls.Add(1)
+	select {
+	case <-time.After(c.delay):
+		return c.weather, c.err
+	case <-ctx.Done():
+		c.cancelled.Store(true)
+		return nil, ctx.Err()
+	}
+}
+
+func (c *delayedClient) GetDailyForecast(context.Context, string) (*domain.WeatherDaily, error) {
+	return nil, nil
+}
+
+func (c *delayedClient) GetHourlyForecast(context.Context, string) (*domain.WeatherHourly, error) {
+	return nil, nil
+}
+
+func (c *delayedClient) GetHourlyTimeline(
+	context.Context, string, int,
+) (*domain.WeatherTimeline, error) {
+	return nil, nil
+}
+
+func newHandler(clients ...Client) *Handler {
+	links := make([]chain.Link[Client], 0, len(clients))
+	for i, client := range clients {
+		links = append(links, chain.Link[Client]{Name: string(rune('a' + i)), Client: client})
+	}
+	return NewHandler(chain.New("weather", links, nil))
+}
+
+func TestHandler_FallsBackOnError(t *testing.T) {
+	primary := &delayedClient{err: pkgErrors.New(internalErrors.ErrServiceUnavailable, "down")}
+	secondary := &delayedClient{weather: &domain.Weather{Description: "secondary"}}
+	handler := newHandler(primary, secondary)
+
+	weather, err := handler.GetWeather(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, "secondary", weather.Description)
+}
+
+func TestHandler_DoesNotFallBackOnUnknownCity(t *testing.T) {
+	primary := &delayedClient{err: pkgErrors.New(internalErrors.ErrNotFound, "city not found")}
+	secondary := &delayedClient{weather: &domain.Weather{Description: "secondary"}}
+	handler := newHandler(primary, secondary)
+
+	_, err := handler.GetWeather(context.Background(), "Atlantis")
+
+	assert.ErrorIs(t, err, internalErrors.ErrNotFound)
+	assert.Equal(t, int32(0), secondary.calls.Load())
+}
@@ -0,0 +1,67 @@
+package weather
+
+import (
+	"context"
+	"time"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+)
+
+type RequestRecorder interface {
+	ObserveRequest(provider, operation, outcome string, latency time.Duration)
+}
+
+type InstrumentedClient struct {
+	delegate Client
+	provider string
+	recorder RequestRecorder
+}
+
+func NewInstrumentedClient(
+	delegate Client, provider string, recorder RequestRecorder,
+) *InstrumentedClient {
+	return &InstrumentedClient{delegate: delegate, provider: provider, recorder: recorder}
+}
+
+func (c *InstrumentedClient) GetWeather(ctx context.Context, city string) (*domain.Weather, error) {
+	start := time.Now()
+	weather, err := c.delegate.GetWeather(ctx, city)
+	c.observe("current", start, err)
+	return weather, err
+}
+
+func (c *InstrumentedClient) GetDailyForecast(
+	ctx context.Context,
+	city string,
+) (*domain.WeatherDaily, error) {
+	start := time.Now()
+	weather, err := c.delegate.GetDailyForecast(ctx, city)
+	c.observe("daily", start, err)
+	return weather, err
+}
+
+func (c *InstrumentedClient) GetHourlyForecast(
+	ctx context.Context,
+	city string,
+) (*domain.WeatherHourly, error) {
+	start := time.Now()
+	weather, err := c.delegate.GetHourlyForecast(ctx, city)
+	c.observe("hourly", start, err)
+	return weather, err
+}
+
+func (c *InstrumentedClient) GetHourlyTimeline(
+	ctx context.Context,
+	city string,
+	hours int,
+) (*domain.WeatherTimeline, error) {
+	start := time.Now()
+	weather, err := c.delegate.GetHourlyTimeline(ctx, city, hours)
+	c.observe("timeline", start, err)
+	return weather, err
+}
+
+func (c *InstrumentedClient) observe(operation string, start time.Time, err error) {
+	c.recorder.ObserveRequest(c.provider, operation, internalErrors.Class(err), time.Since(start))
+}
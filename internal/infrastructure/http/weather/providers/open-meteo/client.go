@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,12 +27,17 @@ type Clock interface {
 	Now() time.Time
 }
 
+type LocationResolver interface {
+	Resolve(ctx context.Context, city string) (*domain.Location, error)
+}
+
 type Client struct {
 	client       *http.Client
 	clock        Clock
 	openMeteoURL string
-	geoCodingURL string
+	resolver     LocationResolver
 	logger       Logger
+	retry        *appHttp.RetryPolicy
 }
 
 const (
@@ -42,31 +48,38 @@ const (
 	hourly         = "hourly"
 )
 
-func NewClient(openMeteoURL, geoCodingURL string, logger Logger, clock Clock) *Client {
+func NewClient(
+	openMeteoURL string,
+	resolver LocationResolver,
+	logger Logger,
+	clock Clock,
+	timeout time.Duration,
+	retry *appHttp.RetryPolicy,
+) *Client {
 	client := &http.Client{
-		Timeout: defaultTimeout,
+		Timeout: appHttp.TimeoutOrDefault(timeout, defaultTimeout),
 	}
 	return &Client{
 		client:       client,
 		openMeteoURL: openMeteoURL,
 		clock:        clock,
-		geoCodingURL: geoCodingURL,
+		resolver:     resolver,
 		logger:       logger,
+		retry:        retry,
 	}
 }
 
 func (h *Client) GetWeather(ctx context.Context, city string) (*domain.Weather, error) {
-	coords, err := h.fetchCoordinates(ctx, city)
+	location, err := h.resolver.Resolve(ctx, city)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := appHttp.Get(ctx, h.client, h.buildRequestURL(coords, currentWeatherParams, current))
+	endpoint := h.buildRequestURL(location, currentWeatherParams, current, 0)
+	resp, err := h.retry.Get(ctx, h.client, endpoint)
 	h.logger.LogResponse(providerName, resp)
 	if err != nil {
-		return nil, pkgErrors.New(
-			internalErrors.ErrServiceUnavailable, "failed to connect to weather API",
-		)
+		return nil, connectionError(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -84,21 +97,22 @@ func (h *Client) GetWeather(ctx context.Context, city string) (*domain.Weather,
 			internalErrors.ErrInternal, "failed to parse weather response",
 		)
 	}
-	return toWeather(&apiResponse), nil
+	weather := toWeather(&apiResponse)
+	weather.Freshness = h.hints(location, resp.Header, nextUpdate(&apiResponse))
+	return weather, nil
 }
 
 func (h *Client) GetDailyForecast(ctx context.Context, city string) (*domain.WeatherDaily, error) {
-	coords, err := h.fetchCoordinates(ctx, city)
+	location, err := h.resolver.Resolve(ctx, city)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := appHttp.Get(ctx, h.client, h.buildRequestURL(coords, dailyForecastParams, daily))
+	endpoint := h.buildRequestURL(location, dailyForecastParams, daily, 1)
+	resp, err := h.retry.Get(ctx, h.client, endpoint)
 	h.logger.LogResponse(providerName, resp)
 	if err != nil {
-		return nil, pkgErrors.New(
-			internalErrors.ErrServiceUnavailable, "failed to connect to weather API",
-		)
+		return nil, connectionError(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -116,55 +130,62 @@ func (h *Client) GetDailyForecast(ctx context.Context, city string) (*domain.Wea
 			internalErrors.ErrInternal, "failed to parse weather response",
 		)
 	}
-	return toWeatherDaily(&apiResponse, city), nil
+	forecast, err := toWeatherDaily(&apiResponse, city)
+	if err != nil {
+		return nil, err
+	}
+	forecast.Freshness = h.hints(location, resp.Header, time.Time{})
+	return forecast, nil
 }
 
 func (h *Client) GetHourlyForecast(
 	ctx context.Context,
 	city string,
 ) (*domain.WeatherHourly, error) {
-	coords, err := h.fetchCoordinates(ctx, city)
+	apiResponse, hints, err := h.fetchHourly(ctx, city, 1)
 	if err != nil {
 		return nil, err
 	}
-
-	resp, err := appHttp.Get(ctx, h.client, h.buildRequestURL(coords, hourlyForecastParams, hourly))
-	h.logger.LogResponse(providerName, resp)
+	forecast, err := toWeatherHourly(apiResponse, city, h.clock.Now())
 	if err != nil {
-		return nil, pkgErrors.New(
-			internalErrors.ErrServiceUnavailable, "failed to connect to weather API",
-		)
+		return nil, err
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Println("Error closing response body:", err)
-		}
-	}()
+	forecast.Freshness = hints
+	return forecast, nil
+}
 
-	if err := h.handleAPIResponse(resp); err != nil {
+func (h *Client) GetHourlyTimeline(
+	ctx context.Context,
+	city string,
+	hours int,
+) (*domain.WeatherTimeline, error) {
+	apiResponse, hints, err := h.fetchHourly(ctx, city, hours/24+2)
+	if err != nil {
 		return nil, err
 	}
-
-	var apiResponse WeatherHourlyResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return nil, pkgErrors.New(
-			internalErrors.ErrInternal, "failed to parse weather response",
-		)
+	timeline, err := toWeatherTimeline(apiResponse, city, h.clock.Now(), hours)
+	if err != nil {
+		return nil, err
 	}
-	return toWeatherHourly(&apiResponse, city, h.clock.Now()), nil
-}
-
-type coordinates struct {
-	latitude  float64
-	longitude float64
+	timeline.Freshness = hints
+	return timeline, nil
 }
 
-func (h *Client) fetchCoordinates(ctx context.Context, city string) (*coordinates, error) {
-	endpoint := fmt.Sprintf("%s/search?name=%s&count=1", h.geoCodingURL, city)
+func (h *Client) fetchHourly(
+	ctx context.Context,
+	city string,
+	days int,
+) (*WeatherHourlyResponse, domain.Freshness, error) {
+	location, err := h.resolver.Resolve(ctx, city)
+	if err != nil {
+		return nil, domain.Freshness{}, err
+	}
 
-	resp, err := appHttp.Get(ctx, h.client, endpoint)
+	endpoint := h.buildRequestURL(location, hourlyForecastParams, hourly, days)
+	resp, err := h.retry.Get(ctx, h.client, endpoint)
+	h.logger.LogResponse(providerName, resp)
 	if err != nil {
-		return nil, err
+		return nil, domain.Freshness{}, connectionError(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -173,23 +194,29 @@ func (h *Client) fetchCoordinates(ctx context.Context, city string) (*coordinate
 	}()
 
 	if err := h.handleAPIResponse(resp); err != nil {
-		return nil, err
+		return nil, domain.Freshness{}, err
 	}
 
-	var apiResponse GeolocationResponse
+	var apiResponse WeatherHourlyResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return nil, pkgErrors.New(
-			internalErrors.ErrInternal, "failed to parse geolocation response",
+		return nil, domain.Freshness{}, pkgErrors.New(
+			internalErrors.ErrInternal, "failed to parse weather response",
 		)
 	}
-	if len(apiResponse.Results) == 0 {
-		return nil, pkgErrors.New(internalErrors.ErrNotFound, "city not found")
-	}
+	return &apiResponse, h.hints(location, resp.Header, time.Time{}), nil
+}
 
-	return &coordinates{
-		latitude:  apiResponse.Results[0].Latitude,
-		longitude: apiResponse.Results[0].Longitude,
-	}, nil
+// hints reports the location's timezone and the time open-meteo expects newer
+// data: the next model update when the response gives it, otherwise whatever
+// its HTTP caching headers say.
+func (h *Client) hints(
+	location *domain.Location, header http.Header, nextUpdate time.Time,
+) domain.Freshness {
+	validUntil := nextUpdate
+	if validUntil.IsZero() {
+		validUntil = appHttp.ValidUntil(header, h.clock.Now())
+	}
+	return domain.Freshness{Timezone: location.Timezone, ValidUntil: validUntil}
 }
 
 func (h *Client) handleAPIResponse(resp *http.Response) error {
@@ -217,16 +244,38 @@ func (h *Client) handleAPIResponse(resp *http.Response) error {
 	return nil
 }
 
-func (h *Client) buildRequestURL(coords *coordinates, params []string, forecast string) string {
+func (h *Client) buildRequestURL(
+	location *domain.Location, params []string, forecast string, days int,
+) string {
 	baseURL := fmt.Sprintf("%s/forecast", h.openMeteoURL)
 
 	values := url.Values{}
-	values.Set("latitude", fmt.Sprintf("%f", coords.latitude))
-	values.Set("longitude", fmt.Sprintf("%f", coords.longitude))
+	values.Set("latitude", fmt.Sprintf("%f", location.Latitude))
+	values.Set("longitude", fmt.Sprintf("%f", location.Longitude))
 	values.Set(forecast, strings.Join(params, ","))
+	values.Set("timezone", timezoneParam(location))
 	if forecast != current {
-		values.Set("forecast_days", "1")
+		values.Set("forecast_days", strconv.Itoa(days))
 	}
 
 	return fmt.Sprintf("%s?%s", baseURL, values.Encode())
 }
+
+// timezoneParam asks open-meteo to group days and report times in the city's
+// timezone, so a daily forecast covers the city's own day.
+func timezoneParam(location *domain.Location) string {
+	if location.Timezone == "" {
+		return "auto"
+	}
+	return location.Timezone
+}
+
+// connectionError keeps an exhausted quota and a cancelled request
+// distinguishable from an unreachable API.
+func connectionError(err error) error {
+	switch internalErrors.Class(err) {
+	case internalErrors.ClassQuotaExceeded, internalErrors.ClassCanceled:
+		return err
+	}
+	return pkgErrors.New(internalErrors.ErrServiceUnavailable, "failed to connect to weather API")
+}
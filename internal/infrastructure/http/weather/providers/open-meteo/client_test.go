@@ -8,25 +8,35 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
 	appHttp "weather-api/internal/infrastructure/http"
+	"weather-api/internal/test/stubs"
 )
 
-var (
-	openMeteoURL = "http://open-meteo-mock/v1"
-	geoCodingURL = "http://geocoding-mock/v1"
-)
+var openMeteoURL = "http://open-meteo-mock/v1"
 
 type MockClock struct{}
 
+// Now is midnight of 2025-06-26 in Kyiv, the timezone of the test responses.
 func (MockClock) Now() time.Time {
-	t, _ := time.Parse("2006-01-02T15:04", "2025-06-26T00:00")
+	t, _ := time.Parse("2006-01-02T15:04", "2025-06-25T21:00")
 	return t
 }
 
+type LocationResolverStub struct{}
+
+func (LocationResolverStub) Resolve(_ context.Context, city string) (*domain.Location, error) {
+	return &domain.Location{
+		Name: city, Latitude: 50.45466, Longitude: 30.5238, Timezone: "Europe/Kyiv",
+	}, nil
+}
+
 func loadJSONFile(t *testing.T, filename string) string {
 	path := filepath.Join("testdata", filename)
 	content, err := os.ReadFile(path) // #nosec G304 -- filename is controlled and safe
@@ -35,21 +45,16 @@ func loadJSONFile(t *testing.T, filename string) string {
 }
 
 func TestGetWeather(t *testing.T) {
-	coordinatesResponse := loadJSONFile(t, "coordinates_response.json")
 	currentResponse := loadJSONFile(t, "current_weather_response.json")
 
 	mockResponses := map[string]appHttp.MockResponse{
-		geoCodingURL: {
-			Body:       coordinatesResponse,
-			StatusCode: http.StatusOK,
-		},
 		openMeteoURL: {
 			Body:       currentResponse,
 			StatusCode: http.StatusOK,
 		},
 	}
 
-	repo := NewClient(openMeteoURL, geoCodingURL, appHttp.NoOpLogger{}, MockClock{})
+	repo := NewClient(openMeteoURL, LocationResolverStub{}, appHttp.NoOpLogger{}, MockClock{}, 0, nil)
 	repo.client = appHttp.MockHTTPClientWithResponses(mockResponses)
 	ctx := context.Background()
 
@@ -59,25 +64,22 @@ func TestGetWeather(t *testing.T) {
 	require.NotNil(t, weather)
 	assert.Equal(t, 20.7, weather.Temperature)
 	assert.Equal(t, "Overcast", weather.Description)
+	assert.Equal(t, "Europe/Kyiv", weather.Timezone)
+	assert.Equal(t, time.Date(2025, 6, 26, 15, 45, 0, 0, time.UTC), weather.ValidUntil.UTC())
 }
 
 func TestGetDailyForecast(t *testing.T) {
-	coordinatesResponse := loadJSONFile(t, "coordinates_response.json")
 	dailyResponse := loadJSONFile(t, "daily_weather_response.json")
 	location := "Kyiv"
 
 	mockResponses := map[string]appHttp.MockResponse{
-		geoCodingURL: {
-			Body:       coordinatesResponse,
-			StatusCode: http.StatusOK,
-		},
 		openMeteoURL: {
 			Body:       dailyResponse,
 			StatusCode: http.StatusOK,
 		},
 	}
 
-	repo := NewClient(openMeteoURL, geoCodingURL, appHttp.NoOpLogger{}, MockClock{})
+	repo := NewClient(openMeteoURL, LocationResolverStub{}, appHttp.NoOpLogger{}, MockClock{}, 0, nil)
 	repo.client = appHttp.MockHTTPClientWithResponses(mockResponses)
 	ctx := context.Background()
 
@@ -89,25 +91,23 @@ func TestGetDailyForecast(t *testing.T) {
 	assert.Equal(t, location, forecast.Location)
 	assert.Equal(t, "Overcast", forecast.Condition)
 	assert.Equal(t, 17.75, forecast.AvgTempC)
+	assert.Equal(t, 35, forecast.ChanceRain)
+	assert.False(t, forecast.WillItRain)
+	assert.Equal(t, 0, forecast.ChanceSnow)
 }
 
 func TestGetHourlyForecast(t *testing.T) {
-	coordinatesResponse := loadJSONFile(t, "coordinates_response.json")
 	hourlyResponse := loadJSONFile(t, "hourly_weather_response.json")
 	location := "Kyiv"
 
 	mockResponses := map[string]appHttp.MockResponse{
-		geoCodingURL: {
-			Body:       coordinatesResponse,
-			StatusCode: http.StatusOK,
-		},
 		openMeteoURL: {
 			Body:       hourlyResponse,
 			StatusCode: http.StatusOK,
 		},
 	}
 
-	repo := NewClient(openMeteoURL, geoCodingURL, appHttp.NoOpLogger{}, MockClock{})
+	repo := NewClient(openMeteoURL, LocationResolverStub{}, appHttp.NoOpLogger{}, MockClock{}, 0, nil)
 	repo.client = appHttp.MockHTTPClientWithResponses(mockResponses)
 	ctx := context.Background()
 
@@ -119,4 +119,123 @@ func TestGetHourlyForecast(t *testing.T) {
 	assert.Equal(t, location, forecast.Location)
 	assert.Equal(t, "Overcast", forecast.Condition)
 	assert.Equal(t, 18.0, forecast.TempC)
+	assert.Equal(t, 20, forecast.ChanceRain)
+	assert.Equal(t, 0, forecast.ChanceSnow)
+}
+
+func TestGetHourlyTimeline(t *testing.T) {
+	hourlyResponse := loadJSONFile(t, "hourly_weather_response.json")
+
+	mockResponses := map[string]appHttp.MockResponse{
+		openMeteoURL: {
+			Body:       hourlyResponse,
+			StatusCode: http.StatusOK,
+		},
+	}
+
+	repo := NewClient(openMeteoURL, LocationResolverStub{}, appHttp.NoOpLogger{}, MockClock{}, 0, nil)
+	repo.client = appHttp.MockHTTPClientWithResponses(mockResponses)
+
+	timeline, err := repo.GetHourlyTimeline(context.Background(), "Kyiv", 6)
+
+	require.NoError(t, err)
+	require.Len(t, timeline.Hours, 6)
+	assert.Equal(t, "Kyiv", timeline.Location)
+	assert.Equal(t, "2025-06-26T00:00", timeline.Hours[0].Time)
+	assert.Equal(t, "2025-06-26T05:00", timeline.Hours[5].Time)
+	assert.Equal(t, 15, timeline.Hours[1].ChanceRain)
+}
+
+func TestClient_RequestsCityTimezone(t *testing.T) {
+	var timezones []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timezones = append(timezones, r.URL.Query().Get("timezone"))
+		if r.URL.Query().Has("current") {
+			_, _ = w.Write([]byte(`{"timezone": "Asia/Tokyo",
+				"current": {"time": "2025-06-26T08:30", "interval": 900, "weather_code": 0}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"timezone": "Asia/Tokyo", "daily": {"time": ["2025-06-26"],
+			"weather_code": [0], "temperature_2m_max": [28], "temperature_2m_min": [21],
+			"precipitation_probability_max": [10]}}`))
+	}))
+	defer server.Close()
+	resolver := &stubs.LocationResolverStub{ResolveFn: func(city string) (*domain.Location, error) {
+		return &domain.Location{Name: city, Timezone: "Asia/Tokyo"}, nil
+	}}
+	// 08:30 in Tokyo, while still the previous day in UTC.
+	repo := NewClient(server.URL, resolver, appHttp.NoOpLogger{}, MockClock{}, 0, nil)
+
+	forecast, err := repo.GetDailyForecast(context.Background(), "Tokyo")
+	require.NoError(t, err)
+	weather, err := repo.GetWeather(context.Background(), "Tokyo")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Asia/Tokyo", "Asia/Tokyo"}, timezones)
+	assert.Equal(t, "2025-06-26", forecast.Date)
+	assert.Equal(t, time.Date(2025, 6, 25, 23, 45, 0, 0, time.UTC), weather.ValidUntil.UTC())
+}
+
+func TestToWeatherTimeline_UsesResponseTimezone(t *testing.T) {
+	var response WeatherHourlyResponse
+	response.Timezone = "Asia/Tokyo"
+	response.Hourly.Time = []string{"2025-06-26T08:00", "2025-06-26T09:00", "2025-06-26T10:00"}
+	response.Hourly.Temperature = []float64{21, 22, 23}
+	response.Hourly.PrecipitationProbability = []int{0, 10, 20}
+	response.Hourly.WeatherCode = []int{0, 1, 2}
+	// 09:15 in Tokyo, while still the previous day in UTC.
+	now := time.Date(2025, 6, 26, 0, 15, 0, 0, time.UTC)
+
+	timeline, err := toWeatherTimeline(&response, "Tokyo", now, 2)
+
+	require.NoError(t, err)
+	require.Len(t, timeline.Hours, 2)
+	assert.Equal(t, "2025-06-26T09:00", timeline.Hours[0].Time)
+	assert.Equal(t, "2025-06-26T10:00", timeline.Hours[1].Time)
+
+	hourly, err := toWeatherHourly(&response, "Tokyo", now)
+	require.NoError(t, err)
+	assert.Equal(t, 22.0, hourly.TempC)
+}
+
+func TestGetDailyForecast_IncompleteResponse(t *testing.T) {
+	mockResponses := map[string]appHttp.MockResponse{
+		openMeteoURL: {
+			Body:       `{"daily": {"time": [], "temperature_2m_max": []}}`,
+			StatusCode: http.StatusOK,
+		},
+	}
+
+	repo := NewClient(openMeteoURL, LocationResolverStub{}, appHttp.NoOpLogger{}, MockClock{}, 0, nil)
+	repo.client = appHttp.MockHTTPClientWithResponses(mockResponses)
+
+	forecast, err := repo.GetDailyForecast(context.Background(), "Kyiv")
+
+	assert.Nil(t, forecast)
+	assert.ErrorIs(t, err, internalErrors.ErrInvalidData)
+}
+
+func TestPrecipitationChance(t *testing.T) {
+	tests := []struct {
+		name        string
+		weatherCode int
+		minTempC    float64
+		maxTempC    float64
+		rain        int
+		snow        int
+	}{
+		{name: "warm rain", weatherCode: 61, minTempC: 12, maxTempC: 18, rain: 80, snow: 0},
+		{name: "snow code", weatherCode: 73, minTempC: 1, maxTempC: 3, rain: 0, snow: 80},
+		{name: "below freezing", weatherCode: 61, minTempC: -4, maxTempC: -1, rain: 0, snow: 80},
+		{name: "near freezing", weatherCode: 61, minTempC: 1, maxTempC: 5, rain: 40, snow: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chance := newPrecipitationChance(80, tt.weatherCode, tt.minTempC, tt.maxTempC)
+
+			assert.Equal(t, tt.rain, chance.rain)
+			assert.Equal(t, tt.snow, chance.snow)
+		})
+	}
 }
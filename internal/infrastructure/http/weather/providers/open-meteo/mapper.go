@@ -1,15 +1,26 @@
 package open_meteo
 
 import (
+	"math"
+	"slices"
 	"time"
 
 	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
 )
 
 const (
 	timeLayout = "2006-01-02T15:04"
+	hourLayout = "2006-01-02T15:00"
+
+	willItThreshold = 50
+	freezingTempC   = 0.0
+	sleetTempC      = 2.0
 )
 
+var snowWeatherCodes = []int{71, 73, 75, 77, 85, 86}
+
 func toWeather(weatherResponse *WeatherResponse) *domain.Weather {
 	return &domain.Weather{
 		Temperature: weatherResponse.Current.Temperature,
@@ -18,60 +29,165 @@ func toWeather(weatherResponse *WeatherResponse) *domain.Weather {
 	}
 }
 
-func toWeatherDaily(response *WeatherDailyResponse, city string) *domain.WeatherDaily {
-	chanceRain := 0
-	if response.Daily.RainSum[0] > 1 {
-		chanceRain = 100
+// nextUpdate is when open-meteo refreshes current conditions: the end of the
+// interval the reported values cover. Times are in the city's timezone, which
+// every request asks for.
+func nextUpdate(response *WeatherResponse) time.Time {
+	current := response.Current
+	if current.Interval <= 0 {
+		return time.Time{}
+	}
+	start, err := time.ParseInLocation(timeLayout, current.Time, zone(response.Timezone))
+	if err != nil {
+		return time.Time{}
 	}
+	return start.Add(time.Duration(current.Interval) * time.Second)
+}
 
-	chanceSnow := 0
-	if response.Daily.SnowfallSum[0] > 0 {
-		chanceSnow = 100
+func toWeatherDaily(response *WeatherDailyResponse, city string) (*domain.WeatherDaily, error) {
+	daily := response.Daily
+	if !hasValues(len(daily.Time), len(daily.TemperatureMax), len(daily.TemperatureMin),
+		len(daily.WeatherCode), len(daily.PrecipitationProbabilityMax)) {
+		return nil, errIncomplete("daily forecast is missing")
 	}
+	chance := newPrecipitationChance(
+		daily.PrecipitationProbabilityMax[0],
+		daily.WeatherCode[0],
+		daily.TemperatureMin[0],
+		daily.TemperatureMax[0],
+	)
 	return &domain.WeatherDaily{
 		Location:   city,
-		Date:       response.Daily.Time[0],
-		MaxTempC:   response.Daily.TemperatureMax[0],
-		MinTempC:   response.Daily.TemperatureMin[0],
-		AvgTempC:   (response.Daily.TemperatureMax[0] + response.Daily.TemperatureMin[0]) / 2,
-		WillItRain: response.Daily.RainSum[0] > 0,
-		ChanceRain: chanceRain,
-		WillItSnow: response.Daily.SnowfallSum[0] > 0,
-		ChanceSnow: chanceSnow,
-		Condition:  weatherCodeDescriptions[response.Daily.WeatherCode[0]],
-		Icon:       getWeatherIconURL(response.Daily.WeatherCode[0]),
-	}
+		Date:       daily.Time[0],
+		MaxTempC:   daily.TemperatureMax[0],
+		MinTempC:   daily.TemperatureMin[0],
+		AvgTempC:   (daily.TemperatureMax[0] + daily.TemperatureMin[0]) / 2,
+		WillItRain: chance.rain >= willItThreshold,
+		ChanceRain: chance.rain,
+		WillItSnow: chance.snow >= willItThreshold,
+		ChanceSnow: chance.snow,
+		Condition:  weatherCodeDescriptions[daily.WeatherCode[0]],
+		Icon:       getWeatherIconURL(daily.WeatherCode[0]),
+	}, nil
 }
 
 func toWeatherHourly(
 	response *WeatherHourlyResponse,
 	city string, targetTime time.Time,
-) *domain.WeatherHourly {
-	currentTime := targetTime.Truncate(time.Hour).Format(timeLayout)
+) (*domain.WeatherHourly, error) {
+	if !hasHourlyValues(response) {
+		return nil, errIncomplete("hourly forecast is incomplete")
+	}
+	currentTime := localHour(targetTime, response.Timezone)
 	for index, hour := range response.Hourly.Time {
 		if hour == currentTime {
-			chanceRain := 0
-			if response.Hourly.Rain[index] > 1 {
-				chanceRain = 100
-			}
-
-			chanceSnow := 0
-			if response.Hourly.Snowfall[index] > 0 {
-				chanceSnow = 100
-			}
-			return &domain.WeatherHourly{
-				Location:   city,
-				Time:       hour,
-				TempC:      response.Hourly.Temperature[index],
-				WillItRain: response.Hourly.Rain[index] > 0,
-				ChanceRain: chanceRain,
-				WillItSnow: response.Hourly.Snowfall[index] > 0,
-				ChanceSnow: chanceSnow,
-				Condition:  weatherCodeDescriptions[response.Hourly.WeatherCode[index]],
-				Icon:       getWeatherIconURL(response.Hourly.WeatherCode[index]),
-			}
+			weatherHourly := toHour(response, city, index)
+			return &weatherHourly, nil
 		}
 	}
 
-	return nil
+	return nil, errIncomplete("forecast for the current hour is missing")
+}
+
+func toWeatherTimeline(
+	response *WeatherHourlyResponse,
+	city string, targetTime time.Time, hours int,
+) (*domain.WeatherTimeline, error) {
+	if !hasHourlyValues(response) {
+		return nil, errIncomplete("hourly forecast is incomplete")
+	}
+	currentTime := localHour(targetTime, response.Timezone)
+	timeline := &domain.WeatherTimeline{Location: city}
+	for index, hour := range response.Hourly.Time {
+		if len(timeline.Hours) == hours {
+			break
+		}
+		if hour >= currentTime {
+			timeline.Hours = append(timeline.Hours, toHour(response, city, index))
+		}
+	}
+	if len(timeline.Hours) == 0 {
+		return nil, errIncomplete("forecast for the upcoming hours is missing")
+	}
+	return timeline, nil
+}
+
+// localHour formats the hour containing t as it reads in the response's
+// timezone, the zone of every timestamp open-meteo returns.
+func localHour(t time.Time, timezone string) string {
+	return t.In(zone(timezone)).Format(hourLayout)
+}
+
+// zone resolves the timezone a response reports, falling back to GMT, which
+// open-meteo uses when a request names no timezone.
+func zone(timezone string) *time.Location {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// hasHourlyValues reports whether every hourly series has a value for each
+// timestamp, so indexing by position is safe.
+func hasHourlyValues(response *WeatherHourlyResponse) bool {
+	hourly := response.Hourly
+	count := len(hourly.Time)
+	return len(hourly.Temperature) == count &&
+		len(hourly.WeatherCode) == count &&
+		len(hourly.PrecipitationProbability) == count
+}
+
+func hasValues(lengths ...int) bool {
+	return !slices.Contains(lengths, 0)
+}
+
+func errIncomplete(message string) error {
+	return pkgErrors.New(internalErrors.ErrInvalidData, message)
+}
+
+func toHour(response *WeatherHourlyResponse, city string, index int) domain.WeatherHourly {
+	hourly := response.Hourly
+	chance := newPrecipitationChance(
+		hourly.PrecipitationProbability[index],
+		hourly.WeatherCode[index],
+		hourly.Temperature[index],
+		hourly.Temperature[index],
+	)
+	return domain.WeatherHourly{
+		Location:   city,
+		Time:       hourly.Time[index],
+		TempC:      hourly.Temperature[index],
+		WillItRain: chance.rain >= willItThreshold,
+		ChanceRain: chance.rain,
+		WillItSnow: chance.snow >= willItThreshold,
+		ChanceSnow: chance.snow,
+		Condition:  weatherCodeDescriptions[hourly.WeatherCode[index]],
+		Icon:       getWeatherIconURL(hourly.WeatherCode[index]),
+	}
+}
+
+type precipitationChance struct {
+	rain int
+	snow int
+}
+
+// newPrecipitationChance splits the precipitation probability between rain and
+// snow, the way weatherapi reports them as separate chances.
+func newPrecipitationChance(
+	probability, weatherCode int, minTempC, maxTempC float64,
+) precipitationChance {
+	snow := int(math.Round(float64(probability) * snowShare(weatherCode, minTempC, maxTempC)))
+	return precipitationChance{rain: probability - snow, snow: snow}
+}
+
+func snowShare(weatherCode int, minTempC, maxTempC float64) float64 {
+	switch {
+	case slices.Contains(snowWeatherCodes, weatherCode), maxTempC <= freezingTempC:
+		return 1
+	case minTempC <= sleetTempC:
+		return 0.5
+	default:
+		return 0
+	}
 }
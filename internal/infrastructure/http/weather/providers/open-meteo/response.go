@@ -1,14 +1,10 @@
 package open_meteo
 
-type GeolocationResponse struct {
-	Results []struct {
-		Latitude  float64 `json:"latitude"`
-		Longitude float64 `json:"longitude"`
-	} `json:"results"`
-}
-
 type WeatherResponse struct {
-	Current struct {
+	Timezone string `json:"timezone"`
+	Current  struct {
+		Time        string  `json:"time"`
+		Interval    int     `json:"interval"`
 		Temperature float64 `json:"temperature_2m"`
 		Humidity    int     `json:"relative_humidity_2m"`
 		WeatherCode int     `json:"weather_code"`
@@ -17,21 +13,20 @@ type WeatherResponse struct {
 
 type WeatherDailyResponse struct {
 	Daily struct {
-		Time           []string  `json:"time"`
-		WeatherCode    []int     `json:"weather_code"`
-		TemperatureMax []float64 `json:"temperature_2m_max"`
-		TemperatureMin []float64 `json:"temperature_2m_min"`
-		RainSum        []float64 `json:"rain_sum"`
-		SnowfallSum    []float64 `json:"snowfall_sum"`
+		Time                        []string  `json:"time"`
+		WeatherCode                 []int     `json:"weather_code"`
+		TemperatureMax              []float64 `json:"temperature_2m_max"`
+		TemperatureMin              []float64 `json:"temperature_2m_min"`
+		PrecipitationProbabilityMax []int     `json:"precipitation_probability_max"`
 	} `json:"daily"`
 }
 
 type WeatherHourlyResponse struct {
-	Hourly struct {
-		Time        []string  `json:"time"`
-		Temperature []float64 `json:"temperature_2m"`
-		Rain        []float64 `json:"rain"`
-		Snowfall    []float64 `json:"snowfall"`
-		WeatherCode []int     `json:"weather_code"`
+	Timezone string `json:"timezone"`
+	Hourly   struct {
+		Time                     []string  `json:"time"`
+		Temperature              []float64 `json:"temperature_2m"`
+		PrecipitationProbability []int     `json:"precipitation_probability"`
+		WeatherCode              []int     `json:"weather_code"`
 	} `json:"hourly"`
 }
@@ -7,10 +7,13 @@ var dailyForecastParams = []string{
 	"weather_code",
 	"temperature_2m_max",
 	"temperature_2m_min",
-	"rain_sum",
-	"snowfall_sum",
+	"precipitation_probability_max",
+}
+var hourlyForecastParams = []string{
+	"temperature_2m",
+	"precipitation_probability",
+	"weather_code",
 }
-var hourlyForecastParams = []string{"temperature_2m", "rain", "snowfall", "weather_code"}
 
 var weatherCodeDescriptions = map[int]string{
 	0:  "Clear sky",
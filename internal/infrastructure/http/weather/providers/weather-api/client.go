@@ -29,17 +29,26 @@ type Client struct {
 	clock   Clock
 	baseURL string
 	logger  Logger
+	retry   *appHttp.RetryPolicy
 }
 
-func NewClient(apiURL string, apiKey string, logger Logger, clock Clock) *Client {
+func NewClient(
+	apiURL string,
+	apiKey string,
+	logger Logger,
+	clock Clock,
+	timeout time.Duration,
+	retry *appHttp.RetryPolicy,
+) *Client {
 	client := &http.Client{
-		Timeout: defaultTimeout,
+		Timeout: appHttp.TimeoutOrDefault(timeout, defaultTimeout),
 	}
 	return &Client{apiKey: apiKey,
 		client:  client,
 		clock:   clock,
 		baseURL: apiURL,
 		logger:  logger,
+		retry:   retry,
 	}
 }
 
@@ -48,6 +57,7 @@ const (
 	currentEndpoint  = "/current.json"
 	forecastEndpoint = "/forecast.json"
 	defaultTimeout   = 10 * time.Second
+	maxForecastDays  = 3
 )
 
 func (c *Client) GetWeather(ctx context.Context, city string) (*domain.Weather, error) {
@@ -57,7 +67,7 @@ func (c *Client) GetWeather(ctx context.Context, city string) (*domain.Weather,
 		c.apiKey,
 		url.QueryEscape(city),
 	)
-	resp, err := appHttp.Get(ctx, c.client, endpoint)
+	resp, err := c.retry.Get(ctx, c.client, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +87,9 @@ func (c *Client) GetWeather(ctx context.Context, city string) (*domain.Weather,
 			internalErrors.ErrInternal, "failed to parse weather data",
 		)
 	}
-	return toWeather(&apiResponse), nil
+	weather := toWeather(&apiResponse)
+	weather.Freshness = c.hints(apiResponse.Location.TzID, resp.Header)
+	return weather, nil
 }
 
 func (c *Client) GetDailyForecast(ctx context.Context, city string) (*domain.WeatherDaily, error) {
@@ -87,7 +99,7 @@ func (c *Client) GetDailyForecast(ctx context.Context, city string) (*domain.Wea
 		c.apiKey,
 		url.QueryEscape(city),
 	)
-	resp, err := appHttp.Get(ctx, c.client, endpoint)
+	resp, err := c.retry.Get(ctx, c.client, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -108,22 +120,62 @@ func (c *Client) GetDailyForecast(ctx context.Context, city string) (*domain.Wea
 			internalErrors.ErrInternal, "failed to parse weather data",
 		)
 	}
-	return toWeatherDaily(&apiResponse), nil
+	forecast, err := toWeatherDaily(&apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	forecast.Freshness = c.hints(apiResponse.Location.TzID, resp.Header)
+	return forecast, nil
 }
 
 func (c *Client) GetHourlyForecast(
 	ctx context.Context,
 	city string,
 ) (*domain.WeatherHourly, error) {
-	endpoint := fmt.Sprintf("%s%s?key=%s&q=%s&days=1",
+	apiResponse, hints, err := c.fetchForecast(ctx, city, 1)
+	if err != nil {
+		return nil, err
+	}
+	forecast, err := toWeatherHourly(apiResponse, c.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	forecast.Freshness = hints
+	return forecast, nil
+}
+
+func (c *Client) GetHourlyTimeline(
+	ctx context.Context,
+	city string,
+	hours int,
+) (*domain.WeatherTimeline, error) {
+	apiResponse, hints, err := c.fetchForecast(ctx, city, min(hours/24+2, maxForecastDays))
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := toWeatherTimeline(apiResponse, c.clock.Now(), hours)
+	if err != nil {
+		return nil, err
+	}
+	timeline.Freshness = hints
+	return timeline, nil
+}
+
+func (c *Client) fetchForecast(
+	ctx context.Context,
+	city string,
+	days int,
+) (*WeatherHourlyResponse, domain.Freshness, error) {
+	endpoint := fmt.Sprintf("%s%s?key=%s&q=%s&days=%d",
 		c.baseURL,
 		forecastEndpoint,
 		c.apiKey,
 		url.QueryEscape(city),
+		days,
 	)
-	resp, err := appHttp.Get(ctx, c.client, endpoint)
+	resp, err := c.retry.Get(ctx, c.client, endpoint)
 	if err != nil {
-		return nil, err
+		return nil, domain.Freshness{}, err
 	}
 	c.logger.LogResponse(providerName, resp)
 	defer func() {
@@ -133,16 +185,25 @@ func (c *Client) GetHourlyForecast(
 	}()
 
 	if err := c.handleAPIResponse(resp); err != nil {
-		return nil, err
+		return nil, domain.Freshness{}, err
 	}
 
 	var apiResponse WeatherHourlyResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return nil, pkgErrors.New(
+		return nil, domain.Freshness{}, pkgErrors.New(
 			internalErrors.ErrInternal, "failed to parse weather data",
 		)
 	}
-	return toWeatherHourly(&apiResponse, c.clock.Now()), nil
+	return &apiResponse, c.hints(apiResponse.Location.TzID, resp.Header), nil
+}
+
+// hints reports the location's timezone and, from the HTTP caching headers,
+// how long weatherapi expects the response to stay current.
+func (c *Client) hints(timezone string, header http.Header) domain.Freshness {
+	return domain.Freshness{
+		Timezone:   timezone,
+		ValidUntil: appHttp.ValidUntil(header, c.clock.Now()),
+	}
 }
 
 func (c *Client) handleAPIResponse(resp *http.Response) error {
@@ -13,13 +13,15 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+	internalErrors "weather-api/internal/errors"
 	appHttp "weather-api/internal/infrastructure/http"
 )
 
 type MockClock struct{}
 
+// Now is midnight of 2025-05-18 in London, which is on BST.
 func (MockClock) Now() time.Time {
-	t, _ := time.Parse("2006-01-02 15:04", "2025-05-18 00:00")
+	t, _ := time.Parse("2006-01-02 15:04", "2025-05-17 23:00")
 	return t
 }
 
@@ -38,6 +40,8 @@ func TestGetWeather(t *testing.T) {
 		"dummy-api-key",
 		appHttp.NoOpLogger{},
 		MockClock{},
+		0,
+		nil,
 	)
 	repo.client = appHttp.MockHTTPClient(appHttp.MockResponse{Body: mockResponse, StatusCode: http.StatusOK})
 	ctx := context.Background()
@@ -48,6 +52,7 @@ func TestGetWeather(t *testing.T) {
 	require.NotNil(t, weather)
 	assert.Equal(t, 11.0, weather.Temperature)
 	assert.Equal(t, "Partly cloudy", weather.Description)
+	assert.Equal(t, "Europe/London", weather.Timezone)
 }
 
 func TestGetDailyForecast(t *testing.T) {
@@ -58,6 +63,8 @@ func TestGetDailyForecast(t *testing.T) {
 		"dummy-api-key",
 		appHttp.NoOpLogger{},
 		MockClock{},
+		0,
+		nil,
 	)
 	repo.client = appHttp.MockHTTPClient(appHttp.MockResponse{Body: mockResponse, StatusCode: http.StatusOK})
 	ctx := context.Background()
@@ -79,6 +86,8 @@ func TestGetHourlyForecast(t *testing.T) {
 		"dummy-api-key",
 		appHttp.NoOpLogger{},
 		MockClock{},
+		0,
+		nil,
 	)
 	repo.client = appHttp.MockHTTPClient(appHttp.MockResponse{Body: mockResponse, StatusCode: http.StatusOK})
 	ctx := context.Background()
@@ -93,6 +102,27 @@ func TestGetHourlyForecast(t *testing.T) {
 	assert.Equal(t, 10.2, forecast.TempC)
 }
 
+func TestGetHourlyTimeline(t *testing.T) {
+	mockResponse := loadJSONFile(t, "forecast_response.json")
+
+	repo := NewClient("http://mocked-weather-api.com",
+		"dummy-api-key",
+		appHttp.NoOpLogger{},
+		MockClock{},
+		0,
+		nil,
+	)
+	repo.client = appHttp.MockHTTPClient(appHttp.MockResponse{Body: mockResponse, StatusCode: http.StatusOK})
+
+	timeline, err := repo.GetHourlyTimeline(context.Background(), "London", 24)
+
+	require.NoError(t, err)
+	assert.Equal(t, "London", timeline.Location)
+	require.Len(t, timeline.Hours, 1)
+	assert.Equal(t, "2025-05-18 00:00", timeline.Hours[0].Time)
+	assert.Equal(t, 10.2, timeline.Hours[0].TempC)
+}
+
 func TestHandleAPIErrorResponse(t *testing.T) {
 	mockErrorResponse := loadJSONFile(t, "not_found_response.json")
 
@@ -101,6 +131,8 @@ func TestHandleAPIErrorResponse(t *testing.T) {
 		"dummy-api-key",
 		appHttp.NoOpLogger{},
 		MockClock{},
+		0,
+		nil,
 	)
 	repo.client = appHttp.MockHTTPClient(appHttp.MockResponse{Body: mockErrorResponse, StatusCode: http.StatusBadRequest})
 	ctx := context.Background()
@@ -130,10 +162,45 @@ func TestDirectMapping(t *testing.T) {
 	require.NoError(t, err)
 
 	weather := toWeather(&currentResponse)
-	dailyForecast := toWeatherDaily(&dailyResponse)
-	hourlyForecast := toWeatherHourly(&hourlyResponse, MockClock{}.Now())
+	dailyForecast, err := toWeatherDaily(&dailyResponse)
+	require.NoError(t, err)
+	hourlyForecast, err := toWeatherHourly(&hourlyResponse, MockClock{}.Now())
+	require.NoError(t, err)
 
 	assert.NotNil(t, weather)
 	assert.NotNil(t, dailyForecast)
 	assert.NotNil(t, hourlyForecast)
 }
+
+func TestMapping_IncompleteForecast(t *testing.T) {
+	var response WeatherHourlyResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"forecast": {"forecastday": []}}`), &response))
+
+	_, err := toWeatherDaily(&WeatherDailyResponse{})
+	assert.ErrorIs(t, err, internalErrors.ErrInvalidData)
+
+	_, err = toWeatherHourly(&response, MockClock{}.Now())
+	assert.ErrorIs(t, err, internalErrors.ErrInvalidData)
+}
+
+func TestMapping_UsesCityTimezone(t *testing.T) {
+	var response WeatherHourlyResponse
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"location": {"name": "Tokyo", "tz_id": "Asia/Tokyo"},
+		"forecast": {"forecastday": [{"hour": [
+			{"time": "2025-05-18 08:00", "temp_c": 18.0},
+			{"time": "2025-05-18 09:00", "temp_c": 19.5}
+		]}]}
+	}`), &response))
+	// 08:30 in Tokyo, while the previous evening in UTC.
+	now := time.Date(2025, 5, 17, 23, 30, 0, 0, time.UTC)
+
+	hourly, err := toWeatherHourly(&response, now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-05-18 08:00", hourly.Time)
+
+	timeline, err := toWeatherTimeline(&response, now, 2)
+	require.NoError(t, err)
+	require.Len(t, timeline.Hours, 2)
+	assert.Equal(t, 18.0, timeline.Hours[0].TempC)
+}
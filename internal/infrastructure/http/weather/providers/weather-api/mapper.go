@@ -4,10 +4,12 @@ import (
 	"time"
 
 	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
 )
 
 const (
-	timeLayout = "2006-01-02 15:04"
+	hourLayout = "2006-01-02 15:00"
 )
 
 func toWeather(weatherRepositoryResponse *WeatherRepositoryResponse) *domain.Weather {
@@ -18,7 +20,10 @@ func toWeather(weatherRepositoryResponse *WeatherRepositoryResponse) *domain.Wea
 	}
 }
 
-func toWeatherDaily(response *WeatherDailyResponse) *domain.WeatherDaily {
+func toWeatherDaily(response *WeatherDailyResponse) (*domain.WeatherDaily, error) {
+	if len(response.Forecast.Forecastday) == 0 {
+		return nil, errIncomplete("daily forecast is missing")
+	}
 	first := response.Forecast.Forecastday[0]
 
 	return &domain.WeatherDaily{
@@ -33,26 +38,71 @@ func toWeatherDaily(response *WeatherDailyResponse) *domain.WeatherDaily {
 		ChanceSnow: first.Day.DailyChanceOfSnow,
 		Condition:  first.Day.Condition.Text,
 		Icon:       "https:" + first.Day.Condition.Icon,
-	}
+	}, nil
 }
 
-func toWeatherHourly(response *WeatherHourlyResponse, targetTime time.Time) *domain.WeatherHourly {
-	currentTime := targetTime.Truncate(time.Hour).Format(timeLayout)
+func toWeatherHourly(
+	response *WeatherHourlyResponse, targetTime time.Time,
+) (*domain.WeatherHourly, error) {
+	if len(response.Forecast.Forecastday) == 0 {
+		return nil, errIncomplete("hourly forecast is missing")
+	}
+	currentTime := localHour(targetTime, response.Location.TzID)
 	for _, hour := range response.Forecast.Forecastday[0].Hour {
 		if hour.Time == currentTime {
-			return &domain.WeatherHourly{
-				Location:   response.Location.Name,
-				Time:       hour.Time,
-				TempC:      hour.TempC,
-				WillItRain: hour.WillItRain == 1,
-				ChanceRain: hour.ChanceOfRain,
-				WillItSnow: hour.WillItSnow == 1,
-				ChanceSnow: hour.ChanceOfSnow,
-				Condition:  hour.Condition.Text,
-				Icon:       "https:" + hour.Condition.Icon,
+			weatherHourly := toHour(response.Location.Name, &hour)
+			return &weatherHourly, nil
+		}
+	}
+
+	return nil, errIncomplete("forecast for the current hour is missing")
+}
+
+func toWeatherTimeline(
+	response *WeatherHourlyResponse, targetTime time.Time, hours int,
+) (*domain.WeatherTimeline, error) {
+	currentTime := localHour(targetTime, response.Location.TzID)
+	timeline := &domain.WeatherTimeline{Location: response.Location.Name}
+	for _, day := range response.Forecast.Forecastday {
+		for _, hour := range day.Hour {
+			if len(timeline.Hours) == hours {
+				return timeline, nil
+			}
+			if hour.Time >= currentTime {
+				timeline.Hours = append(timeline.Hours, toHour(response.Location.Name, &hour))
 			}
 		}
 	}
+	if len(timeline.Hours) == 0 {
+		return nil, errIncomplete("forecast for the upcoming hours is missing")
+	}
+	return timeline, nil
+}
+
+// localHour formats the hour containing t as it reads in the city's timezone,
+// the zone of every timestamp weatherapi returns.
+func localHour(t time.Time, timezone string) string {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = t.Location()
+	}
+	return t.In(location).Format(hourLayout)
+}
+
+func toHour(location string, hour *HourResponse) domain.WeatherHourly {
+	return domain.WeatherHourly{
+		Location:   location,
+		Time:       hour.Time,
+		TempC:      hour.TempC,
+		WillItRain: hour.WillItRain == 1,
+		ChanceRain: hour.ChanceOfRain,
+		WillItSnow: hour.WillItSnow == 1,
+		ChanceSnow: hour.ChanceOfSnow,
+		Condition:  hour.Condition.Text,
+		Icon:       "https:" + hour.Condition.Icon,
+	}
+}
 
-	return nil
+func errIncomplete(message string) error {
+	return pkgErrors.New(internalErrors.ErrInvalidData, message)
 }
@@ -1,6 +1,9 @@
 package weather_api
 
 type WeatherRepositoryResponse struct {
+	Location struct {
+		TzID string `json:"tz_id"`
+	} `json:"location"`
 	Current struct {
 		TempC     float64 `json:"temp_c"`
 		Humidity  float64 `json:"humidity"`
@@ -13,6 +16,7 @@ type WeatherRepositoryResponse struct {
 type WeatherDailyResponse struct {
 	Location struct {
 		Name string `json:"name"`
+		TzID string `json:"tz_id"`
 	} `json:"location"`
 	Forecast struct {
 		Forecastday []struct {
@@ -37,21 +41,24 @@ type WeatherDailyResponse struct {
 type WeatherHourlyResponse struct {
 	Location struct {
 		Name string `json:"name"`
+		TzID string `json:"tz_id"`
 	} `json:"location"`
 	Forecast struct {
 		Forecastday []struct {
-			Hour []struct {
-				Time         string  `json:"time"`
-				TempC        float64 `json:"temp_c"`
-				WillItRain   int     `json:"will_it_rain"`
-				ChanceOfRain int     `json:"chance_of_rain"`
-				WillItSnow   int     `json:"will_it_snow"`
-				ChanceOfSnow int     `json:"chance_of_snow"`
-				Condition    struct {
-					Text string `json:"text"`
-					Icon string `json:"icon"`
-				} `json:"condition"`
-			} `json:"hour"`
+			Hour []HourResponse `json:"hour"`
 		} `json:"forecastday"`
 	} `json:"forecast"`
 }
+
+type HourResponse struct {
+	Time         string  `json:"time"`
+	TempC        float64 `json:"temp_c"`
+	WillItRain   int     `json:"will_it_rain"`
+	ChanceOfRain int     `json:"chance_of_rain"`
+	WillItSnow   int     `json:"will_it_snow"`
+	ChanceOfSnow int     `json:"chance_of_snow"`
+	Condition    struct {
+		Text string `json:"text"`
+		Icon string `json:"icon"`
+	} `json:"condition"`
+}
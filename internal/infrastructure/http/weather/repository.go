@@ -9,6 +9,7 @@ import (
 type Client interface {
 	GetDailyForecast(ctx context.Context, city string) (*domain.WeatherDaily, error)
 	GetHourlyForecast(ctx context.Context, city string) (*domain.WeatherHourly, error)
+	GetHourlyTimeline(ctx context.Context, city string, hours int) (*domain.WeatherTimeline, error)
 	GetWeather(ctx context.Context, city string) (*domain.Weather, error)
 }
 
@@ -37,3 +38,11 @@ func (r *Repository) GetHourlyForecast(
 ) (*domain.WeatherHourly, error) {
 	return r.client.GetHourlyForecast(ctx, city)
 }
+
+func (r *Repository) GetHourlyTimeline(
+	ctx context.Context,
+	city string,
+	hours int,
+) (*domain.WeatherTimeline, error) {
+	return r.client.GetHourlyTimeline(ctx, city, hours)
+}
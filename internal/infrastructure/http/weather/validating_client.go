@@ -0,0 +1,63 @@
+package weather
+
+import (
+	"context"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+)
+
+type validatable[T any] interface {
+	*T
+	Validate() error
+}
+
+// ValidatingClient rejects incomplete or implausible provider payloads with
+// ErrInvalidData, so the chain can fall back before bad data is cached.
+type ValidatingClient struct {
+	delegate Client
+}
+
+func NewValidatingClient(delegate Client) *ValidatingClient {
+	return &ValidatingClient{delegate: delegate}
+}
+
+func (c *ValidatingClient) GetWeather(ctx context.Context, city string) (*domain.Weather, error) {
+	return validated(c.delegate.GetWeather(ctx, city))
+}
+
+func (c *ValidatingClient) GetDailyForecast(
+	ctx context.Context,
+	city string,
+) (*domain.WeatherDaily, error) {
+	return validated(c.delegate.GetDailyForecast(ctx, city))
+}
+
+func (c *ValidatingClient) GetHourlyForecast(
+	ctx context.Context,
+	city string,
+) (*domain.WeatherHourly, error) {
+	return validated(c.delegate.GetHourlyForecast(ctx, city))
+}
+
+func (c *ValidatingClient) GetHourlyTimeline(
+	ctx context.Context,
+	city string,
+	hours int,
+) (*domain.WeatherTimeline, error) {
+	return validated(c.delegate.GetHourlyTimeline(ctx, city, hours))
+}
+
+func validated[T any, P validatable[T]](value P, err error) (P, error) {
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, pkgErrors.New(internalErrors.ErrInvalidData, "empty provider response")
+	}
+	if err := value.Validate(); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
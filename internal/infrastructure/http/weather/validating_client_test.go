@@ -0,0 +1,59 @@
+//go:build unit
+// +build unit
+
+package weather
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/domain"
+	internalErrors "weather-api/internal/errors"
+)
+
+func TestValidatingClient_RejectsImplausibleData(t *testing.T) {
+	tests := []struct {
+		name    string
+		weather *domain.Weather
+	}{
+		{name: "empty response", weather: nil},
+		{name: "temperature too high", weather: &domain.Weather{Temperature: 80, Humidity: 40}},
+		{name: "temperature too low", weather: &domain.Weather{Temperature: -120, Humidity: 40}},
+		{name: "humidity out of range", weather: &domain.Weather{Temperature: 20, Humidity: 140}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewValidatingClient(&delayedClient{weather: tt.weather})
+
+			weather, err := client.GetWeather(context.Background(), "Kyiv")
+
+			assert.Nil(t, weather)
+			assert.ErrorIs(t, err, internalErrors.ErrInvalidData)
+		})
+	}
+}
+
+func TestValidatingClient_PassesPlausibleData(t *testing.T) {
+	expected := &domain.Weather{Temperature: 21.5, Humidity: 55, Description: "Sunny"}
+	client := NewValidatingClient(&delayedClient{weather: expected})
+
+	weather, err := client.GetWeather(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, weather)
+}
+
+func TestValidatingClient_FallsBackOnInvalidData(t *testing.T) {
+	primary := NewValidatingClient(&delayedClient{weather: &domain.Weather{Temperature: 300}})
+	secondary := &delayedClient{weather: &domain.Weather{Temperature: 20, Description: "secondary"}}
+	handler := newHandler(primary, secondary)
+
+	weather, err := handler.GetWeather(context.Background(), "Kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, "secondary", weather.Description)
+}
@@ -0,0 +1,157 @@
+package monitoring
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"weather-api/internal/application/common"
+	internalErrors "weather-api/internal/errors"
+)
+
+const (
+	StatusUnknown   = "unknown"
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+
+	degradedRatio  = 0.1
+	unhealthyRatio = 0.5
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+type sample struct {
+	failed  bool
+	latency time.Duration
+}
+
+type providerHealth struct {
+	samples     []sample
+	next        int
+	count       int
+	lastOutcome string
+	lastSeen    time.Time
+}
+
+// HealthTracker keeps a rolling window of recent request outcomes per provider.
+// Not found and invalid input answers are valid responses and do not count as
+// failures; cancelled requests (e.g. the losing side of a hedge) are ignored.
+type HealthTracker struct {
+	mu        sync.Mutex
+	window    int
+	clock     Clock
+	providers map[string]*providerHealth
+}
+
+func NewHealthTracker(window int, clock Clock) *HealthTracker {
+	return &HealthTracker{
+		window:    max(window, 1),
+		clock:     clock,
+		providers: make(map[string]*providerHealth),
+	}
+}
+
+func (t *HealthTracker) Register(provider string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.health(provider)
+}
+
+func (t *HealthTracker) ObserveRequest(
+	provider, _ string, outcome string, latency time.Duration,
+) {
+	if outcome == internalErrors.ClassCanceled {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	health := t.health(provider)
+	health.samples[health.next] = sample{failed: isFailure(outcome), latency: latency}
+	health.next = (health.next + 1) % len(health.samples)
+	health.count = min(health.count+1, len(health.samples))
+	health.lastOutcome = outcome
+	health.lastSeen = t.clock.Now()
+}
+
+func (t *HealthTracker) Statuses() []common.ProviderStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	statuses := make([]common.ProviderStatus, 0, len(t.providers))
+	for name, health := range t.providers {
+		statuses = append(statuses, health.status(name))
+	}
+	slices.SortFunc(statuses, func(a, b common.ProviderStatus) int {
+		if a.Provider < b.Provider {
+			return -1
+		}
+		if a.Provider > b.Provider {
+			return 1
+		}
+		return 0
+	})
+	return statuses
+}
+
+func (t *HealthTracker) health(provider string) *providerHealth {
+	health, ok := t.providers[provider]
+	if !ok {
+		health = &providerHealth{samples: make([]sample, t.window)}
+		t.providers[provider] = health
+	}
+	return health
+}
+
+func (h *providerHealth) status(provider string) common.ProviderStatus {
+	status := common.ProviderStatus{
+		Provider:    provider,
+		Status:      StatusUnknown,
+		Requests:    h.count,
+		LastOutcome: h.lastOutcome,
+		LastSeen:    h.lastSeen,
+	}
+	if h.count == 0 {
+		return status
+	}
+
+	var total time.Duration
+	for _, s := range h.window() {
+		total += s.latency
+		if s.failed {
+			status.Failures++
+		}
+	}
+	status.AvgLatency = total / time.Duration(h.count)
+
+	ratio := float64(status.Failures) / float64(h.count)
+	switch {
+	case ratio >= unhealthyRatio:
+		status.Status = StatusUnhealthy
+	case ratio >= degradedRatio:
+		status.Status = StatusDegraded
+	default:
+		status.Status = StatusHealthy
+	}
+	return status
+}
+
+func (h *providerHealth) window() []sample {
+	if h.count < len(h.samples) {
+		return h.samples[:h.count]
+	}
+	return h.samples
+}
+
+func isFailure(outcome string) bool {
+	switch outcome {
+	case internalErrors.ClassOK, internalErrors.ClassNotFound, internalErrors.ClassInvalidInput:
+		return false
+	default:
+		return true
+	}
+}
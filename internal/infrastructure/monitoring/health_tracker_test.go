@@ -0,0 +1,130 @@
+//go:build unit
+// +build unit
+
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalErrors "weather-api/internal/errors"
+	appHttp "weather-api/internal/infrastructure/http"
+	"weather-api/internal/infrastructure/http/location"
+	"weather-api/internal/infrastructure/http/location/providers/geocoding"
+	"weather-api/internal/infrastructure/http/weather"
+	openMeteo "weather-api/internal/infrastructure/http/weather/providers/open-meteo"
+	"weather-api/internal/test/stubs"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+func TestHealthTracker_UnknownWithoutSamples(t *testing.T) {
+	tracker := NewHealthTracker(10, fixedClock{})
+	tracker.Register("open-meteo")
+
+	statuses := tracker.Statuses()
+
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, StatusUnknown, statuses[0].Status)
+}
+
+func TestHealthTracker_NotFoundIsNotFailure(t *testing.T) {
+	tracker := NewHealthTracker(10, fixedClock{})
+	tracker.ObserveRequest("open-meteo", "current", internalErrors.ClassOK, time.Second)
+	tracker.ObserveRequest("open-meteo", "current", internalErrors.ClassNotFound, time.Second)
+
+	status := tracker.Statuses()[0]
+
+	assert.Equal(t, StatusHealthy, status.Status)
+	assert.Equal(t, 2, status.Requests)
+	assert.Equal(t, 0, status.Failures)
+	assert.Equal(t, time.Second, status.AvgLatency)
+}
+
+func TestHealthTracker_RollingWindow(t *testing.T) {
+	tracker := NewHealthTracker(4, fixedClock{})
+	for range 4 {
+		tracker.ObserveRequest("weather-api", "daily", internalErrors.ClassUnavailable, 0)
+	}
+	assert.Equal(t, StatusUnhealthy, tracker.Statuses()[0].Status)
+
+	for range 3 {
+		tracker.ObserveRequest("weather-api", "daily", internalErrors.ClassOK, 0)
+	}
+	status := tracker.Statuses()[0]
+
+	assert.Equal(t, StatusDegraded, status.Status)
+	assert.Equal(t, 4, status.Requests)
+	assert.Equal(t, 1, status.Failures)
+}
+
+func TestHealthTracker_IgnoresCanceled(t *testing.T) {
+	tracker := NewHealthTracker(10, fixedClock{})
+	tracker.ObserveRequest("weather-api", "hourly", internalErrors.ClassCanceled, 0)
+
+	assert.Empty(t, tracker.Statuses())
+}
+
+// hangingServer holds every request until the client goes away and reports
+// each arrival on the returned channel.
+func hangingServer(t *testing.T) (*httptest.Server, <-chan struct{}) {
+	arrived := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server, arrived
+}
+
+// cancelOnArrival returns a context that is cancelled as soon as the server
+// receives a request.
+func cancelOnArrival(arrived <-chan struct{}) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+	return ctx
+}
+
+func TestHealthTracker_IgnoresCancelledProviderRequests(t *testing.T) {
+	tracker := NewHealthTracker(10, fixedClock{})
+	retry := appHttp.NewRetryPolicy("test", 3, time.Millisecond, time.Millisecond, nil)
+
+	weatherServer, weatherArrived := hangingServer(t)
+	weatherClient := weather.NewInstrumentedClient(
+		openMeteo.NewClient(weatherServer.URL, stubs.NewLocationResolverStub(),
+			appHttp.NoOpLogger{}, fixedClock{}, time.Minute, retry),
+		"open-meteo", tracker,
+	)
+	geocodingServer, geocodingArrived := hangingServer(t)
+	locationClient := location.NewInstrumentedClient(
+		geocoding.NewClient(geocodingServer.URL, appHttp.NoOpLogger{}, time.Minute, retry),
+		"geocoding", tracker,
+	)
+	tracker.Register("open-meteo")
+	tracker.Register("geocoding")
+
+	_, err := weatherClient.GetWeather(cancelOnArrival(weatherArrived), "Kyiv")
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = locationClient.Resolve(cancelOnArrival(geocodingArrived), "Kyiv")
+	require.ErrorIs(t, err, context.Canceled)
+
+	for _, status := range tracker.Statuses() {
+		assert.Equal(t, StatusUnknown, status.Status, status.Provider)
+		assert.Zero(t, status.Requests, status.Provider)
+	}
+}
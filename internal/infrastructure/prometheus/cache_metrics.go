@@ -6,31 +6,20 @@ import (
 )
 
 type СacheMetrics struct {
-	cacheHit  prometheus.Counter
-	cacheMiss prometheus.Counter
+	results *prometheus.CounterVec
 }
 
 func NewCacheMetrics(namespace, subsystem string) *СacheMetrics {
 	return &СacheMetrics{
-		cacheHit: promauto.NewCounter(prometheus.CounterOpts{
+		results: promauto.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: subsystem,
-			Name:      "cache_hit",
-			Help:      "Total number of cache hits",
-		}),
-		cacheMiss: promauto.NewCounter(prometheus.CounterOpts{
-			Namespace: namespace,
-			Subsystem: subsystem,
-			Name:      "cache_miss",
-			Help:      "Total number of cache misses",
-		}),
+			Name:      "cache_requests_total",
+			Help:      "Total number of cache lookups by key prefix, entry type and result",
+		}, []string{"prefix", "type", "result"}),
 	}
 }
 
-func (m *СacheMetrics) CacheHit() {
-	m.cacheHit.Inc()
-}
-
-func (m *СacheMetrics) CacheMiss() {
-	m.cacheMiss.Inc()
+func (m *СacheMetrics) CacheResult(prefix, entryType, result string) {
+	m.results.WithLabelValues(prefix, entryType, result).Inc()
 }
@@ -0,0 +1,65 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type CacheTierMetrics struct {
+	hits      *prometheus.CounterVec
+	misses    *prometheus.CounterVec
+	available *prometheus.GaugeVec
+}
+
+func NewCacheTierMetrics(namespace string) *CacheTierMetrics {
+	return &CacheTierMetrics{
+		hits: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache_tier",
+			Name:      "hits_total",
+			Help:      "Total number of hits per cache tier",
+		}, []string{"tier"}),
+		misses: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache_tier",
+			Name:      "misses_total",
+			Help:      "Total number of misses per cache tier",
+		}, []string{"tier"}),
+		available: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "cache_tier",
+			Name:      "available",
+			Help:      "Whether a shared cache tier can be reached (1) or is bypassed (0)",
+		}, []string{"tier"}),
+	}
+}
+
+func (m *CacheTierMetrics) Tier(tier string) *TierMetrics {
+	return &TierMetrics{
+		hit:       m.hits.WithLabelValues(tier),
+		miss:      m.misses.WithLabelValues(tier),
+		available: m.available.WithLabelValues(tier),
+	}
+}
+
+type TierMetrics struct {
+	hit       prometheus.Counter
+	miss      prometheus.Counter
+	available prometheus.Gauge
+}
+
+func (m *TierMetrics) CacheHit() {
+	m.hit.Inc()
+}
+
+func (m *TierMetrics) CacheMiss() {
+	m.miss.Inc()
+}
+
+func (m *TierMetrics) BackendAvailable(available bool) {
+	if available {
+		m.available.Set(1)
+		return
+	}
+	m.available.Set(0)
+}
@@ -0,0 +1,49 @@
+package prometheus
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type ProviderMetrics struct {
+	latency   *prometheus.HistogramVec
+	requests  *prometheus.CounterVec
+	fallbacks *prometheus.CounterVec
+}
+
+func NewProviderMetrics(namespace string) *ProviderMetrics {
+	return &ProviderMetrics{
+		latency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "provider",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of provider requests",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10},
+		}, []string{"provider", "operation"}),
+		requests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "provider",
+			Name:      "requests_total",
+			Help:      "Total number of provider requests by outcome",
+		}, []string{"provider", "operation", "outcome"}),
+		fallbacks: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "provider",
+			Name:      "fallbacks_total",
+			Help:      "Total number of fallbacks to the next provider in a chain",
+		}, []string{"chain", "reason"}),
+	}
+}
+
+func (m *ProviderMetrics) ObserveRequest(
+	provider, operation, outcome string, latency time.Duration,
+) {
+	m.latency.WithLabelValues(provider, operation).Observe(latency.Seconds())
+	m.requests.WithLabelValues(provider, operation, outcome).Inc()
+}
+
+func (m *ProviderMetrics) Fallback(chain, reason string) {
+	m.fallbacks.WithLabelValues(chain, reason).Inc()
+}
@@ -0,0 +1,25 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type QuotaMetrics struct {
+	remaining *prometheus.GaugeVec
+}
+
+func NewQuotaMetrics(namespace string) *QuotaMetrics {
+	return &QuotaMetrics{
+		remaining: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "provider",
+			Name:      "quota_remaining",
+			Help:      "Remaining provider requests in the current quota window",
+		}, []string{"provider", "window"}),
+	}
+}
+
+func (m *QuotaMetrics) QuotaRemaining(provider, window string, remaining int) {
+	m.remaining.WithLabelValues(provider, window).Set(float64(remaining))
+}
@@ -0,0 +1,52 @@
+package prometheus
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type RedisMetrics struct {
+	latency *prometheus.HistogramVec
+	payload *prometheus.HistogramVec
+	errors  *prometheus.CounterVec
+}
+
+func NewRedisMetrics(namespace string) *RedisMetrics {
+	return &RedisMetrics{
+		latency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "redis",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of Redis cache operations",
+			Buckets:   []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.5, 1, 3},
+		}, []string{"operation"}),
+		payload: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "redis",
+			Name:      "payload_bytes",
+			Help:      "Size of values read from and written to the Redis cache",
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 8),
+		}, []string{"operation"}),
+		errors: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "redis",
+			Name:      "errors_total",
+			Help:      "Total number of failed Redis cache operations",
+		}, []string{"operation"}),
+	}
+}
+
+func (m *RedisMetrics) ObserveOperation(
+	operation string, latency time.Duration, size int, err error,
+) {
+	m.latency.WithLabelValues(operation).Observe(latency.Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(operation).Inc()
+		return
+	}
+	if size > 0 {
+		m.payload.WithLabelValues(operation).Observe(float64(size))
+	}
+}
@@ -0,0 +1,25 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type RetryMetrics struct {
+	retries *prometheus.CounterVec
+}
+
+func NewRetryMetrics(namespace string) *RetryMetrics {
+	return &RetryMetrics{
+		retries: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "provider",
+			Name:      "retries_total",
+			Help:      "Total number of retried provider requests",
+		}, []string{"provider", "reason"}),
+	}
+}
+
+func (m *RetryMetrics) RetryAttempt(provider, reason string) {
+	m.retries.WithLabelValues(provider, reason).Inc()
+}
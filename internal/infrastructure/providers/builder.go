@@ -0,0 +1,386 @@
+package providers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"weather-api/internal/config"
+	internalErrors "weather-api/internal/errors"
+	"weather-api/internal/infrastructure/chain"
+	"weather-api/internal/infrastructure/db/cache"
+	cacheLocation "weather-api/internal/infrastructure/db/redis/location"
+	"weather-api/internal/infrastructure/db/redis/quota"
+	cacheValidator "weather-api/internal/infrastructure/db/redis/validator"
+	cacheWeather "weather-api/internal/infrastructure/db/redis/weather"
+	"weather-api/internal/infrastructure/db/redis/weather/ttl"
+	appHttp "weather-api/internal/infrastructure/http"
+	"weather-api/internal/infrastructure/http/location"
+	locationGeocoding "weather-api/internal/infrastructure/http/location/providers/geocoding"
+	"weather-api/internal/infrastructure/http/validator"
+	weatherapisearch "weather-api/internal/infrastructure/http/validator/providers/weather-api-search"
+	"weather-api/internal/infrastructure/http/weather"
+	openmeteo "weather-api/internal/infrastructure/http/weather/providers/open-meteo"
+	weatherapi "weather-api/internal/infrastructure/http/weather/providers/weather-api"
+)
+
+const (
+	retryBaseDelay = 200 * time.Millisecond
+	retryMaxDelay  = 2 * time.Second
+)
+
+type Logger interface {
+	LogResponse(provider string, resp *http.Response)
+}
+
+type Clock interface {
+	Now() time.Time
+}
+
+type MetricsRecorder interface {
+	CacheResult(prefix, entryType, result string)
+}
+
+type RequestRecorder interface {
+	ObserveRequest(provider, operation, outcome string, latency time.Duration)
+}
+
+type FallbackRecorder interface {
+	Fallback(chain, reason string)
+}
+
+type Metrics struct {
+	Retry     appHttp.RetryRecorder
+	Quota     quota.MetricsRecorder
+	Weather   cacheWeather.MetricsRecorder
+	Validator MetricsRecorder
+	Location  MetricsRecorder
+	Requests  []RequestRecorder
+	Fallback  FallbackRecorder
+}
+
+// Caching holds what the provider caches share: the store, the codec of cached
+// values, the coalescer for concurrent misses and how long expired forecasts
+// may still be served. NegativeTTL is how long unknown and invalid cities are
+// remembered. Health tells whether Redis is up; quotas are not enforced while
+// it is down.
+type Caching struct {
+	Store       cache.Cache
+	Codec       cache.Codec
+	Coalescer   *cache.Coalescer
+	Staleness   cacheWeather.Staleness
+	NegativeTTL time.Duration
+	Health      cache.Health
+}
+
+type requestRecorders []RequestRecorder
+
+func (r requestRecorders) ObserveRequest(
+	provider, operation, outcome string, latency time.Duration,
+) {
+	for _, recorder := range r {
+		recorder.ObserveRequest(provider, operation, outcome, latency)
+	}
+}
+
+type Builder struct {
+	cfg       config.ProvidersConfig
+	caching   Caching
+	locations location.Store
+	logger    Logger
+	clock     Clock
+	limiter   *quota.Limiter
+	metrics   Metrics
+	resolver  location.Client
+}
+
+func NewBuilder(
+	cfg config.ProvidersConfig,
+	redisClient redis.UniversalClient,
+	caching Caching,
+	locations location.Store,
+	logger Logger,
+	clock Clock,
+	metrics Metrics,
+) *Builder {
+	return &Builder{
+		cfg:       cfg,
+		caching:   caching,
+		locations: locations,
+		logger:    logger,
+		clock:     clock,
+		limiter:   quota.NewLimiter(redisClient, caching.Health, clock, metrics.Quota),
+		metrics:   metrics,
+	}
+}
+
+// BuildWeatherChain returns the weather providers in chain order, each behind
+// its own cache. Expired forecasts are served only once the whole chain fails.
+func (b *Builder) BuildWeatherChain() (weather.Client, error) {
+	var links []chain.Link[weather.Client]
+	var caches []*cacheWeather.ProxyClient
+	for _, name := range b.cfg.WeatherChain {
+		providerCfg, err := b.provider(name)
+		if err != nil {
+			return nil, err
+		}
+		if !providerCfg.Enabled {
+			continue
+		}
+
+		var client weather.Client
+		switch name {
+		case config.ProviderOpenMeteo:
+			resolver, err := b.BuildLocationResolver()
+			if err != nil {
+				return nil, err
+			}
+			client = openmeteo.NewClient(
+				providerCfg.URL,
+				resolver,
+				b.logger,
+				b.clock,
+				providerCfg.Timeout,
+				b.retryPolicy(name, providerCfg),
+			)
+		case config.ProviderWeatherAPI:
+			client = weatherapi.NewClient(
+				providerCfg.URL,
+				providerCfg.APIKey,
+				b.logger,
+				b.clock,
+				providerCfg.Timeout,
+				b.retryPolicy(name, providerCfg),
+			)
+		default:
+			return nil, fmt.Errorf("provider %q does not support weather forecasts", name)
+		}
+
+		client = weather.NewValidatingClient(client)
+		client = weather.NewInstrumentedClient(client, name, b.requests())
+
+		proxy := cacheWeather.NewProxyClient(
+			client,
+			b.caching.Store,
+			b.caching.Codec,
+			b.caching.Coalescer,
+			ttl.NewTTLProvider(providerCfg.CacheTTL, b.clock),
+			b.clock,
+			providerCfg.CachePrefix,
+			b.metrics.Weather,
+		)
+		proxy.SetStaleness(b.caching.Staleness)
+		proxy.SetNegativeTTL(b.caching.NegativeTTL)
+		caches = append(caches, proxy)
+		links = append(links, chain.Link[weather.Client]{Name: name, Client: proxy})
+	}
+
+	if len(links) == 0 {
+		return nil, fmt.Errorf("no enabled weather providers configured")
+	}
+	if len(links) == 1 {
+		return cacheWeather.NewStaleFallbackClient(links[0].Client, caches), nil
+	}
+
+	clients := newChain("weather", links, b.fallbackPolicy(), b.metrics)
+	if b.cfg.Hedge.Enabled {
+		clients.SetHedging(b.hedgePolicy())
+	}
+	return cacheWeather.NewStaleFallbackClient(weather.NewHandler(clients), caches), nil
+}
+
+func (b *Builder) BuildValidationChain() (validator.Client, error) {
+	var links []chain.Link[validator.Client]
+	for _, name := range b.cfg.ValidationChain {
+		providerCfg, err := b.provider(name)
+		if err != nil {
+			return nil, err
+		}
+		if !providerCfg.Enabled {
+			continue
+		}
+
+		var client validator.Client
+		switch name {
+		case config.ProviderGeoCoding:
+			// Geo-coding validates through the location resolver, which is
+			// instrumented itself and shares the locations table and cache.
+			resolver, err := b.BuildLocationResolver()
+			if err != nil {
+				return nil, err
+			}
+			client = validator.NewLocationClient(resolver)
+		case config.ProviderWeatherAPISearch:
+			client = validator.NewInstrumentedClient(weatherapisearch.NewClient(
+				providerCfg.URL,
+				providerCfg.APIKey,
+				b.logger,
+				providerCfg.Timeout,
+				b.retryPolicy(name, providerCfg),
+			), name, b.requests())
+		default:
+			return nil, fmt.Errorf("provider %q does not support city validation", name)
+		}
+
+		proxy := cacheValidator.NewProxyClient(
+			client,
+			b.caching.Store,
+			b.caching.Codec,
+			b.caching.Coalescer,
+			providerCfg.CacheTTL,
+			providerCfg.CachePrefix,
+			b.metrics.Validator,
+		)
+		proxy.SetNegativeTTL(b.caching.NegativeTTL)
+		links = append(links, chain.Link[validator.Client]{Name: name, Client: proxy})
+	}
+
+	if len(links) == 0 {
+		return nil, fmt.Errorf("no enabled validation providers configured")
+	}
+	if len(links) == 1 {
+		return links[0].Client, nil
+	}
+
+	return validator.NewHandler(newChain("validation", links, b.fallbackPolicy(), b.metrics)), nil
+}
+
+// EnabledProviders lists the enabled providers of the weather and validation
+// chains in configuration order.
+func (b *Builder) EnabledProviders() []string {
+	var names []string
+	for _, name := range slices.Concat(b.cfg.WeatherChain, b.cfg.ValidationChain) {
+		providerCfg, ok := b.cfg.Provider(name)
+		if ok && providerCfg.Enabled && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// CachePrefixes lists the key prefixes of every cache the builder sets up.
+func (b *Builder) CachePrefixes() []string {
+	prefixes := []string{b.cfg.Location.CachePrefix}
+	for _, name := range b.EnabledProviders() {
+		providerCfg, _ := b.cfg.Provider(name)
+		if !slices.Contains(prefixes, providerCfg.CachePrefix) {
+			prefixes = append(prefixes, providerCfg.CachePrefix)
+		}
+	}
+	return prefixes
+}
+
+// BuildLocationResolver returns the location resolver shared by every provider
+// that needs coordinates. Resolved cities are cached in Redis and persisted, so
+// the search providers are asked about each city only once.
+func (b *Builder) BuildLocationResolver() (location.Client, error) {
+	if b.resolver != nil {
+		return b.resolver, nil
+	}
+
+	var links []chain.Link[location.Client]
+	for _, name := range b.cfg.SearchChain {
+		providerCfg, err := b.provider(name)
+		if err != nil {
+			return nil, err
+		}
+		if !providerCfg.Enabled {
+			continue
+		}
+		if name != config.ProviderGeoCoding {
+			return nil, fmt.Errorf("provider %q does not support location search", name)
+		}
+
+		var client location.Client = locationGeocoding.NewClient(
+			providerCfg.URL,
+			b.logger,
+			providerCfg.Timeout,
+			b.retryPolicy(name, providerCfg),
+		)
+		client = location.NewInstrumentedClient(client, name, b.requests())
+		links = append(links, chain.Link[location.Client]{Name: name, Client: client})
+	}
+
+	if len(links) == 0 {
+		return nil, fmt.Errorf("no enabled search providers configured")
+	}
+
+	var resolver location.Client = links[0].Client
+	if len(links) > 1 {
+		resolver = location.NewHandler(newChain("search", links, b.fallbackPolicy(), b.metrics))
+	}
+	b.resolver = cacheLocation.NewProxyClient(
+		location.NewPersistentClient(resolver, b.locations),
+		b.caching.Store,
+		b.caching.Codec,
+		b.cfg.Location.CacheTTL,
+		b.cfg.Location.CachePrefix,
+		b.metrics.Location,
+	)
+	return b.resolver, nil
+}
+
+func (b *Builder) provider(name string) (config.ProviderConfig, error) {
+	providerCfg, ok := b.cfg.Provider(name)
+	if !ok {
+		return config.ProviderConfig{}, fmt.Errorf("unknown provider %q", name)
+	}
+	return providerCfg, nil
+}
+
+// retryPolicy charges the provider's quota for every HTTP attempt, retries
+// included.
+func (b *Builder) retryPolicy(
+	name string, providerCfg config.ProviderConfig,
+) *appHttp.RetryPolicy {
+	policy := appHttp.NewRetryPolicy(
+		name, providerCfg.RetryAttempts, retryBaseDelay, retryMaxDelay, b.metrics.Retry,
+	)
+	if budget := b.budget(name, providerCfg); budget != nil {
+		policy.SetQuota(budget)
+	}
+	return policy
+}
+
+func newChain[C any](
+	name string, links []chain.Link[C], policy chain.Policy, metrics Metrics,
+) *chain.Chain[C] {
+	clients := chain.New(name, links, policy)
+	if metrics.Fallback != nil {
+		clients.SetFallbackRecorder(metrics.Fallback)
+	}
+	return clients
+}
+
+func (b *Builder) fallbackPolicy() chain.Policy {
+	return chain.FallbackExcept(append(
+		slices.Clone(b.cfg.NoFallbackOn), internalErrors.ClassCanceled,
+	)...)
+}
+
+func (b *Builder) hedgePolicy() chain.HedgePolicy {
+	hedge := b.cfg.Hedge
+	if hedge.Quantile <= 0 {
+		return chain.NewFixedHedge(hedge.Delay)
+	}
+	return chain.NewLatencyHedge(hedge.Window, hedge.Quantile, hedge.Delay, hedge.MinDelay)
+}
+
+func (b *Builder) requests() requestRecorders {
+	return requestRecorders(b.metrics.Requests)
+}
+
+func (b *Builder) budget(name string, providerCfg config.ProviderConfig) *quota.Budget {
+	if providerCfg.HourlyQuota <= 0 && providerCfg.DailyQuota <= 0 {
+		return nil
+	}
+
+	group := providerCfg.QuotaGroup
+	if group == "" {
+		group = name
+	}
+	return b.limiter.Budget(name, group, providerCfg.HourlyQuota, providerCfg.DailyQuota)
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"weather-api/internal/application/command"
+	"weather-api/internal/application/common"
+	"weather-api/internal/application/query"
+	"weather-api/internal/interface/rest/dto/mapper"
+	"weather-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CacheAdminService interface {
+	ListKeys(
+		ctx context.Context, actor common.AdminActor, prefix string,
+	) (*query.CacheKeysQueryResult, error)
+	GetEntry(
+		ctx context.Context, actor common.AdminActor, key string,
+	) (*query.CacheEntryQueryResult, error)
+	Purge(ctx context.Context, cmd *command.PurgeCacheCommand) (*query.CachePurgeQueryResult, error)
+	Refresh(ctx context.Context, cmd *command.RefreshCacheCommand) (*query.CachePurgeQueryResult, error)
+}
+
+type CacheAdminController struct {
+	service CacheAdminService
+}
+
+func NewCacheAdminController(service CacheAdminService) *CacheAdminController {
+	return &CacheAdminController{
+		service: service,
+	}
+}
+
+func (h *CacheAdminController) ListKeys(c *gin.Context) {
+	result, err := h.service.ListKeys(c.Request.Context(), adminActor(c), c.Query("prefix"))
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ToCacheKeysResponse(result))
+}
+
+func (h *CacheAdminController) GetEntry(c *gin.Context) {
+	result, err := h.service.GetEntry(c.Request.Context(), adminActor(c), c.Query("key"))
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	resp, err := mapper.ToCacheEntryResponse(result)
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CacheAdminController) Purge(c *gin.Context) {
+	result, err := h.service.Purge(c.Request.Context(), &command.PurgeCacheCommand{
+		Actor:  adminActor(c),
+		Prefix: c.Query("prefix"),
+		City:   c.Query("city"),
+	})
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ToCachePurgeResponse(result))
+}
+
+func (h *CacheAdminController) Refresh(c *gin.Context) {
+	result, err := h.service.Refresh(c.Request.Context(), &command.RefreshCacheCommand{
+		Actor: adminActor(c),
+		City:  c.Query("city"),
+	})
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ToCachePurgeResponse(result))
+}
+
+func adminActor(c *gin.Context) common.AdminActor {
+	return common.AdminActor{
+		Name:       c.GetString(middleware.AdminActorKey),
+		RemoteAddr: c.ClientIP(),
+	}
+}
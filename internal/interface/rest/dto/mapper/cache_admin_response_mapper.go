@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"weather-api/internal/application/query"
+	"weather-api/internal/interface/rest/dto/response"
+)
+
+func ToCacheKeysResponse(result *query.CacheKeysQueryResult) *response.CacheKeysResponse {
+	keys := result.Keys
+	if keys == nil {
+		keys = []string{}
+	}
+	return &response.CacheKeysResponse{
+		Prefix:    result.Prefix,
+		Keys:      keys,
+		Truncated: result.Truncated,
+	}
+}
+
+// ToCacheEntryResponse embeds JSON values as they are and quotes anything else.
+func ToCacheEntryResponse(result *query.CacheEntryQueryResult) (*response.CacheEntryResponse, error) {
+	value := json.RawMessage(result.Entry.Value)
+	if !json.Valid(value) {
+		quoted, err := json.Marshal(string(result.Entry.Value))
+		if err != nil {
+			return nil, err
+		}
+		value = quoted
+	}
+	return &response.CacheEntryResponse{
+		Key:        result.Entry.Key,
+		Value:      value,
+		TTLSeconds: int64(result.Entry.TTL.Seconds()),
+	}, nil
+}
+
+func ToCachePurgeResponse(result *query.CachePurgeQueryResult) *response.CachePurgeResponse {
+	return &response.CachePurgeResponse{Deleted: result.Deleted}
+}
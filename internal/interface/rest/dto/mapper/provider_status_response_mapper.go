@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	"weather-api/internal/application/common"
+	"weather-api/internal/interface/rest/dto/response"
+)
+
+func ToProvidersStatusResponse(statuses []common.ProviderStatus) *response.ProvidersStatusResponse {
+	providers := make([]response.ProviderStatusResponse, 0, len(statuses))
+	for _, status := range statuses {
+		providers = append(providers, toProviderStatusResponse(status))
+	}
+	return &response.ProvidersStatusResponse{Providers: providers}
+}
+
+func toProviderStatusResponse(status common.ProviderStatus) response.ProviderStatusResponse {
+	resp := response.ProviderStatusResponse{
+		Provider:     status.Provider,
+		Status:       status.Status,
+		Requests:     status.Requests,
+		Failures:     status.Failures,
+		AvgLatencyMs: status.AvgLatency.Milliseconds(),
+		LastOutcome:  status.LastOutcome,
+	}
+	if !status.LastSeen.IsZero() {
+		lastSeen := status.LastSeen
+		resp.LastSeen = &lastSeen
+	}
+	return resp
+}
@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"weather-api/internal/application/query"
+	"weather-api/internal/domain"
+	"weather-api/internal/interface/rest/dto/response"
+)
+
+func ToManagedSubscriptionsResponse(
+	result *query.ManagedSubscriptionsQueryResult,
+) *response.ManagedSubscriptionsResponse {
+	subscriptions := make([]response.SubscriptionResponse, 0, len(result.Subscriptions))
+	for _, subscription := range result.Subscriptions {
+		subscriptions = append(subscriptions, ToSubscriptionResponse(subscription))
+	}
+	return &response.ManagedSubscriptionsResponse{
+		Email:         result.Email,
+		Subscriptions: subscriptions,
+	}
+}
+
+func ToSubscriptionResponse(subscription *domain.Subscription) response.SubscriptionResponse {
+	return response.SubscriptionResponse{
+		ID:           subscription.ID,
+		City:         subscription.City,
+		Frequency:    string(subscription.Frequency),
+		DeliveryTime: subscription.DeliveryTime,
+		Timezone:     subscription.Timezone,
+		Schedule:     subscription.Schedule,
+		Confirmed:    subscription.Confirmed,
+		Paused:       subscription.Paused,
+	}
+}
@@ -0,0 +1,25 @@
+package mapper
+
+import (
+	"weather-api/internal/application/query"
+	"weather-api/internal/interface/rest/dto/response"
+)
+
+func ToWeatherHourlyResponse(
+	timeline *query.WeatherTimelineQueryResult,
+) *response.WeatherHourlyResponse {
+	hours := make([]response.WeatherHourResponse, 0, len(timeline.Hours))
+	for _, hour := range timeline.Hours {
+		hours = append(hours, response.WeatherHourResponse{
+			Time:        hour.Time,
+			Temperature: hour.TempC,
+			WillItRain:  hour.WillItRain,
+			ChanceRain:  hour.ChanceRain,
+			WillItSnow:  hour.WillItSnow,
+			ChanceSnow:  hour.ChanceSnow,
+			Condition:   hour.Condition,
+			Icon:        hour.Icon,
+		})
+	}
+	return &response.WeatherHourlyResponse{City: timeline.Location, Hours: hours}
+}
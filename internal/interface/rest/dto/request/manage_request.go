@@ -0,0 +1,27 @@
+package request
+
+import "weather-api/internal/application/command"
+
+type ManageLinkRequest struct {
+	Email string `form:"email" binding:"required,email"`
+}
+
+type EditSubscriptionRequest struct {
+	Frequency    string `form:"frequency" binding:"omitempty,oneof=hourly daily custom"`
+	DeliveryTime string `form:"delivery_time" binding:"omitempty,datetime=15:04"`
+	Timezone     string `form:"timezone" binding:"omitempty,timezone"`
+	Schedule     string `form:"schedule" binding:"max=100"`
+}
+
+func (req *EditSubscriptionRequest) ToEditSubscriptionCommand(
+	session string, id uint,
+) *command.EditSubscriptionCommand {
+	return &command.EditSubscriptionCommand{
+		Session:      session,
+		ID:           id,
+		Frequency:    req.Frequency,
+		DeliveryTime: req.DeliveryTime,
+		Timezone:     req.Timezone,
+		Schedule:     req.Schedule,
+	}
+}
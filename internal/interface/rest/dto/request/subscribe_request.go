@@ -1,17 +1,50 @@
 package request
 
-import "weather-api/internal/application/command"
+import (
+	"strings"
+
+	"weather-api/internal/application/command"
+)
 
 type SubscribeRequest struct {
-	Email     string `form:"email" binding:"required,email"`
-	City      string `form:"city" binding:"required"`
-	Frequency string `form:"frequency" binding:"required,oneof=hourly daily"`
+	Email        string `form:"email" binding:"required,email"`
+	City         string `form:"city" binding:"required"`
+	Frequency    string `form:"frequency" binding:"required,oneof=hourly daily custom"`
+	DeliveryTime string `form:"delivery_time" binding:"omitempty,datetime=15:04"`
+	Timezone     string `form:"timezone" binding:"omitempty,timezone"`
+	Schedule     string `form:"schedule" binding:"required_if=Frequency custom,max=100"`
 }
 
 func (req *SubscribeRequest) ToSubscribeCommand() *command.SubscribeCommand {
 	return &command.SubscribeCommand{
-		Email:     req.Email,
-		City:      req.City,
-		Frequency: req.Frequency,
+		Email:        req.Email,
+		City:         req.City,
+		Frequency:    req.Frequency,
+		DeliveryTime: req.DeliveryTime,
+		Timezone:     req.Timezone,
+		Schedule:     req.Schedule,
+	}
+}
+
+type UpdateSubscriptionRequest struct {
+	Email        string `form:"email" binding:"omitempty,email"`
+	City         string `form:"city"`
+	Frequency    string `form:"frequency" binding:"omitempty,oneof=hourly daily custom"`
+	DeliveryTime string `form:"delivery_time" binding:"omitempty,datetime=15:04"`
+	Timezone     string `form:"timezone" binding:"omitempty,timezone"`
+	Schedule     string `form:"schedule" binding:"max=100"`
+}
+
+func (req *UpdateSubscriptionRequest) ToUpdateSubscriptionCommand(
+	token string,
+) *command.UpdateSubscriptionCommand {
+	return &command.UpdateSubscriptionCommand{
+		Token:        token,
+		Email:        req.Email,
+		City:         strings.TrimSpace(req.City),
+		Frequency:    req.Frequency,
+		DeliveryTime: req.DeliveryTime,
+		Timezone:     req.Timezone,
+		Schedule:     req.Schedule,
 	}
 }
@@ -0,0 +1,19 @@
+package response
+
+import "encoding/json"
+
+type CacheKeysResponse struct {
+	Prefix    string   `json:"prefix"`
+	Keys      []string `json:"keys"`
+	Truncated bool     `json:"truncated"`
+}
+
+type CacheEntryResponse struct {
+	Key        string          `json:"key"`
+	Value      json.RawMessage `json:"value"`
+	TTLSeconds int64           `json:"ttl_seconds"`
+}
+
+type CachePurgeResponse struct {
+	Deleted int `json:"deleted"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+type ProviderStatusResponse struct {
+	Provider     string     `json:"provider"`
+	Status       string     `json:"status"`
+	Requests     int        `json:"requests"`
+	Failures     int        `json:"failures"`
+	AvgLatencyMs int64      `json:"avg_latency_ms"`
+	LastOutcome  string     `json:"last_outcome,omitempty"`
+	LastSeen     *time.Time `json:"last_seen,omitempty"`
+}
+
+type ProvidersStatusResponse struct {
+	Providers []ProviderStatusResponse `json:"providers"`
+}
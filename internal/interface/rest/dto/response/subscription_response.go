@@ -0,0 +1,17 @@
+package response
+
+type SubscriptionResponse struct {
+	ID           uint   `json:"id"`
+	City         string `json:"city"`
+	Frequency    string `json:"frequency"`
+	DeliveryTime string `json:"delivery_time"`
+	Timezone     string `json:"timezone"`
+	Schedule     string `json:"schedule,omitempty"`
+	Confirmed    bool   `json:"confirmed"`
+	Paused       bool   `json:"paused"`
+}
+
+type ManagedSubscriptionsResponse struct {
+	Email         string                 `json:"email"`
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+}
@@ -0,0 +1,17 @@
+package response
+
+type WeatherHourResponse struct {
+	Time        string  `json:"time"`
+	Temperature float64 `json:"temperature"`
+	WillItRain  bool    `json:"will_it_rain"`
+	ChanceRain  int     `json:"chance_of_rain"`
+	WillItSnow  bool    `json:"will_it_snow"`
+	ChanceSnow  int     `json:"chance_of_snow"`
+	Condition   string  `json:"condition"`
+	Icon        string  `json:"icon"`
+}
+
+type WeatherHourlyResponse struct {
+	City  string                `json:"city"`
+	Hours []WeatherHourResponse `json:"hours"`
+}
@@ -0,0 +1,164 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"weather-api/internal/application/command"
+	"weather-api/internal/application/query"
+	"weather-api/internal/domain"
+	"weather-api/internal/interface/rest/dto/mapper"
+	"weather-api/internal/interface/rest/dto/request"
+	"weather-api/pkg/middleware"
+
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	manageTemplate = "manage.html"
+	bearerPrefix   = "Bearer "
+)
+
+type ManageService interface {
+	RequestLink(ctx context.Context, email string) error
+	List(ctx context.Context, session string) (*query.ManagedSubscriptionsQueryResult, error)
+	Edit(ctx context.Context, cmd *command.EditSubscriptionCommand) (*domain.Subscription, error)
+	SetPaused(ctx context.Context, session string, id uint, paused bool) error
+	Delete(ctx context.Context, session string, id uint) error
+}
+
+// ManageController serves the self-service management page and its API. The
+// API expects the session from the magic link as a bearer token.
+type ManageController struct {
+	service ManageService
+}
+
+func NewManageController(service ManageService) *ManageController {
+	return &ManageController{service: service}
+}
+
+func (m *ManageController) RequestLink(c *gin.Context) {
+	var req request.ManageLinkRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.Error(pkgErrors.New(internalErrors.ErrInvalidInput, "Invalid input")) //nolint:errcheck
+		return
+	}
+
+	if err := m.service.RequestLink(c.Request.Context(), req.Email); err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"description": "If this address has subscriptions, a management link has been sent.",
+	})
+}
+
+// Page renders the management page. The link carries the session in the URL
+// fragment, which the page posts back as a form field, so it never shows up
+// in URLs or access logs. Without a session it only offers to request a link.
+func (m *ManageController) Page(c *gin.Context) {
+	session := c.PostForm("session")
+	if session == "" {
+		c.HTML(http.StatusOK, manageTemplate, gin.H{})
+		return
+	}
+
+	result, err := m.service.List(c.Request.Context(), session)
+	if err != nil {
+		status, _ := middleware.ToHTTPStatus(err)
+		message := "Something went wrong. Please try again later."
+		if status == http.StatusUnauthorized {
+			message = "This link is invalid or has expired. Please request a new one."
+		}
+		c.HTML(status, manageTemplate, gin.H{"Error": message})
+		return
+	}
+
+	c.HTML(http.StatusOK, manageTemplate, gin.H{
+		"Session": session,
+		"Manage":  mapper.ToManagedSubscriptionsResponse(result),
+	})
+}
+
+func (m *ManageController) List(c *gin.Context) {
+	result, err := m.service.List(c.Request.Context(), manageSession(c))
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ToManagedSubscriptionsResponse(result))
+}
+
+func (m *ManageController) Edit(c *gin.Context) {
+	id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+	var req request.EditSubscriptionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.Error(pkgErrors.New(internalErrors.ErrInvalidInput, "Invalid input")) //nolint:errcheck
+		return
+	}
+
+	subscription, err := m.service.Edit(
+		c.Request.Context(), req.ToEditSubscriptionCommand(manageSession(c), id))
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ToSubscriptionResponse(subscription))
+}
+
+func (m *ManageController) Pause(c *gin.Context) {
+	m.setPaused(c, true)
+}
+
+func (m *ManageController) Resume(c *gin.Context) {
+	m.setPaused(c, false)
+}
+
+func (m *ManageController) Delete(c *gin.Context) {
+	id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	if err := m.service.Delete(c.Request.Context(), manageSession(c), id); err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Subscription deleted"})
+}
+
+func (m *ManageController) setPaused(c *gin.Context, paused bool) {
+	id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	if err := m.service.SetPaused(c.Request.Context(), manageSession(c), id, paused); err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"paused": paused})
+}
+
+func manageSession(c *gin.Context) string {
+	session, _ := strings.CutPrefix(c.GetHeader("Authorization"), bearerPrefix)
+	return session
+}
+
+func subscriptionID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil || id == 0 {
+		c.Error(pkgErrors.New(internalErrors.ErrInvalidInput, "Invalid id")) //nolint:errcheck
+		return 0, false
+	}
+	return uint(id), true
+}
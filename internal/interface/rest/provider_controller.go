@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"weather-api/internal/application/query"
+	"weather-api/internal/interface/rest/dto/mapper"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProviderService interface {
+	GetStatus(ctx context.Context) (*query.ProviderStatusQueryResult, error)
+}
+
+type ProviderController struct {
+	service ProviderService
+}
+
+func NewProviderController(service ProviderService) *ProviderController {
+	return &ProviderController{
+		service: service,
+	}
+}
+
+func (h *ProviderController) GetStatus(c *gin.Context) {
+	status, err := h.service.GetStatus(c.Request.Context())
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	c.JSON(http.StatusOK, mapper.ToProvidersStatusResponse(status.Providers))
+}
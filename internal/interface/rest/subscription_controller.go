@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"weather-api/internal/application/command"
+	"weather-api/internal/application/query"
+	"weather-api/internal/interface/rest/dto/mapper"
 	"weather-api/internal/interface/rest/dto/request"
 
 	internalErrors "weather-api/internal/errors"
@@ -18,6 +20,9 @@ type SubscriptionService interface {
 	Subscribe(ctx context.Context, subscribeCommand *command.SubscribeCommand) error
 	Confirm(ctx context.Context, token string) error
 	Unsubscribe(ctx context.Context, token string) error
+	Update(
+		ctx context.Context, updateCommand *command.UpdateSubscriptionCommand,
+	) (*query.SubscriptionUpdateQueryResult, error)
 }
 
 type SubscriptionController struct {
@@ -75,3 +80,31 @@ func (s *SubscriptionController) Unsubscribe(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed successfully"})
 }
+
+func (s *SubscriptionController) Update(c *gin.Context) {
+	token := c.Param("token")
+	if strings.TrimSpace(token) == "" {
+		c.Error(pkgErrors.New(internalErrors.ErrInvalidInput, "Invalid token")) //nolint:errcheck
+		return
+	}
+	var req request.UpdateSubscriptionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.Error(pkgErrors.New(internalErrors.ErrInvalidInput, "Invalid input")) //nolint:errcheck
+		return
+	}
+
+	result, err := s.service.Update(c.Request.Context(), req.ToUpdateSubscriptionCommand(token))
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+
+	message := "Subscription updated successfully"
+	if result.ConfirmationSent {
+		message = "Subscription updated. Confirmation email sent to the new address."
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":      message,
+		"subscription": mapper.ToSubscriptionResponse(result.Subscription),
+	})
+}
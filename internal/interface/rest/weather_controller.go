@@ -3,8 +3,11 @@ package rest
 import (
 	"context"
 	"net/http"
+	"strconv"
+	"time"
 
 	"weather-api/internal/application/query"
+	"weather-api/internal/domain"
 	"weather-api/internal/interface/rest/dto/mapper"
 
 	internalErrors "weather-api/internal/errors"
@@ -15,6 +18,9 @@ import (
 
 type WeatherService interface {
 	GetWeather(ctx context.Context, city string) (*query.WeatherQueryResult, error)
+	GetHourlyForecast(
+		ctx context.Context, city string, hours int,
+	) (*query.WeatherTimelineQueryResult, error)
 }
 
 type WeatherController struct {
@@ -40,5 +46,42 @@ func (h *WeatherController) GetWeather(c *gin.Context) {
 		c.Error(err) //nolint:errcheck
 		return
 	}
+	setAge(c, weather.Result.FetchedAt)
 	c.JSON(http.StatusOK, mapper.ToWeatherResponse(weather.Result))
 }
+
+func (h *WeatherController) GetHourlyForecast(c *gin.Context) {
+	city := c.Query("city")
+	if city == "" {
+		c.Error(pkgErrors.New(internalErrors.ErrInvalidInput, "Invalid request")) //nolint:errcheck
+		return
+	}
+
+	hours := domain.DefaultTimelineHours
+	if raw := c.Query("hours"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.Error(pkgErrors.New(internalErrors.ErrInvalidInput, "Invalid hours")) //nolint:errcheck
+			return
+		}
+		hours = parsed
+	}
+
+	timeline, err := h.service.GetHourlyForecast(c.Request.Context(), city, hours)
+	if err != nil {
+		c.Error(err) //nolint:errcheck
+		return
+	}
+	setAge(c, timeline.FetchedAt)
+	c.JSON(http.StatusOK, mapper.ToWeatherHourlyResponse(timeline))
+}
+
+// setAge reports how old the served data is, the way HTTP caches do, when it
+// may have come from a cache.
+func setAge(c *gin.Context, fetchedAt time.Time) {
+	if fetchedAt.IsZero() {
+		return
+	}
+	age := max(time.Since(fetchedAt), 0)
+	c.Header("Age", strconv.Itoa(int(age.Seconds())))
+}
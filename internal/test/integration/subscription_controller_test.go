@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	appEmail "weather-api/internal/application/email"
 	"weather-api/internal/application/services/subscription"
@@ -68,7 +69,7 @@ func (suite *SubscriptionControllerTestSuite) SetupSuite() {
 	emailNotifier := appEmail.NewNotifier(serverHost, stubs.NewSenderStub())
 	subscriptionRepo := postgresconnector.NewSubscriptionRepository(db)
 	subscriptionService := subscription.NewService(
-		subscriptionRepo, cityValidator, emailNotifier, serverHost,
+		subscriptionRepo, cityValidator, stubs.NewLocationResolverStub(), emailNotifier, serverHost,
 	)
 	subscriptionController := rest.NewSubscriptionController(subscriptionService)
 	txManager := middleware.NewTxManager(db)
@@ -82,6 +83,7 @@ func (suite *SubscriptionControllerTestSuite) SetupSuite() {
 		api.POST("/subscribe", subscriptionController.Subscribe)
 		api.GET("/confirm/:token", subscriptionController.Confirm)
 		api.GET("/unsubscribe/:token", subscriptionController.Unsubscribe)
+		api.PATCH("/subscription/:token", subscriptionController.Update)
 	}
 
 	suite.Router = router
@@ -117,6 +119,26 @@ func (suite *SubscriptionControllerTestSuite) TestSubscribe() {
 	suite.Equal(int64(1), count)
 }
 
+func (suite *SubscriptionControllerTestSuite) TestSubscribe_CustomSchedule() {
+	formData := "email=test@example.com&city=London&frequency=custom&schedule=Weekdays+at+07:00"
+	body := strings.NewReader(formData)
+
+	req, reqErr := http.NewRequest(http.MethodPost, "/api/subscribe", body)
+	suite.Require().NoError(reqErr)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp := httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+
+	suite.Equal(http.StatusOK, resp.Code)
+
+	var saved domain.Subscription
+	err := suite.DB.Where("email = ?", "test@example.com").First(&saved).Error
+	suite.Require().NoError(err)
+	suite.Equal(domain.FrequencyCustom, saved.Frequency)
+	suite.Equal("weekdays at 07:00", saved.Schedule)
+}
+
 func (suite *SubscriptionControllerTestSuite) TestSubscribe_InvalidInput() {
 	formData := "email=test@example.com"
 	body := strings.NewReader(formData)
@@ -235,6 +257,59 @@ func (suite *SubscriptionControllerTestSuite) TestUnsubscribe() {
 	suite.Equal(int64(0), count)
 }
 
+func (suite *SubscriptionControllerTestSuite) TestUpdateSubscription() {
+	token := "update-token"
+	suite.insertTestData(&domain.Subscription{
+		Email:        "test@example.com",
+		City:         "London",
+		Frequency:    domain.FrequencyHourly,
+		DeliveryTime: domain.DefaultDeliveryTime,
+		Timezone:     "Europe/London",
+		Token:        token,
+		Confirmed:    true,
+	})
+	suite.insertTestData(&domain.Subscription{
+		Email:        "test@example.com",
+		City:         "London",
+		Frequency:    domain.FrequencyDaily,
+		DeliveryTime: domain.DefaultDeliveryTime,
+		Timezone:     "Europe/London",
+		Token:        "update-token-daily",
+		Confirmed:    true,
+	})
+
+	patch := func(form string) *httptest.ResponseRecorder {
+		req, reqErr := http.NewRequest(
+			http.MethodPatch, fmt.Sprintf("/api/subscription/%s", token), strings.NewReader(form))
+		suite.Require().NoError(reqErr)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	suite.Equal(http.StatusConflict, patch("frequency=daily").Code)
+
+	resp := patch("frequency=custom&schedule=weekly+on+sunday&delivery_time=09:30")
+	suite.Equal(http.StatusOK, resp.Code)
+
+	var saved domain.Subscription
+	err := suite.DB.Where("token = ?", token).First(&saved).Error
+	suite.Require().NoError(err)
+	suite.Equal(domain.FrequencyCustom, saved.Frequency)
+	suite.Equal("weekly on sunday", saved.Schedule)
+	suite.Equal("09:30", saved.DeliveryTime)
+	suite.True(saved.Confirmed)
+
+	resp = patch("email=new@example.com")
+	suite.Equal(http.StatusOK, resp.Code)
+
+	err = suite.DB.Where("email = ?", "new@example.com").First(&saved).Error
+	suite.Require().NoError(err)
+	suite.False(saved.Confirmed)
+	suite.NotEqual(token, saved.Token)
+}
+
 func (suite *SubscriptionControllerTestSuite) TestUnsubscribe_InvalidToken() {
 	req, reqErr := http.NewRequest(http.MethodGet, "/api/unsubscribe/ ", nil)
 	suite.Require().NoError(reqErr)
@@ -258,6 +333,138 @@ func (suite *SubscriptionControllerTestSuite) TestUnsubscribe_TokenNotFound() {
 	suite.Contains(resp.Body.String(), "Token not found")
 }
 
+func (suite *SubscriptionControllerTestSuite) TestFindDueSubscriptions() {
+	subscriptions := []*domain.Subscription{
+		{Email: "kyiv@example.com", City: "Kyiv", DeliveryTime: "08:00", Timezone: "Europe/Kyiv"},
+		{Email: "ny@example.com", City: "New York", DeliveryTime: "08:00", Timezone: "America/New_York"},
+		{Email: "tokyo@example.com", City: "Tokyo", DeliveryTime: "00:00", Timezone: "Asia/Tokyo"},
+	}
+	for i, sub := range subscriptions {
+		sub.Frequency = domain.FrequencyDaily
+		sub.Token = fmt.Sprintf("due-token-%d", i)
+		sub.Confirmed = true
+		suite.insertTestData(sub)
+	}
+	suite.insertTestData(&domain.Subscription{
+		Email: "pending@example.com", City: "Kyiv", Frequency: domain.FrequencyDaily,
+		DeliveryTime: "08:00", Timezone: "Europe/Kyiv", Token: "due-token-pending",
+	})
+	suite.insertTestData(&domain.Subscription{
+		Email: "paused@example.com", City: "Kyiv", Frequency: domain.FrequencyDaily,
+		DeliveryTime: "08:00", Timezone: "Europe/Kyiv", Token: "due-token-paused",
+		Confirmed: true, Paused: true,
+	})
+	repo := postgresconnector.NewSubscriptionRepository(suite.DB)
+	ctx := context.Background()
+
+	// 08:00 in Kyiv (UTC+3 in summer) is 05:00 UTC.
+	kyivMorning := time.Date(2025, 6, 26, 5, 0, 0, 0, time.UTC)
+	grouped, err := repo.FindDueSubscriptions(ctx, kyivMorning.Add(-time.Minute), kyivMorning)
+	suite.Require().NoError(err)
+	suite.Require().Len(grouped, 1)
+	suite.Equal("Kyiv", grouped[0].City)
+	suite.Require().Len(grouped[0].Subscriptions, 1)
+	suite.Equal("kyiv@example.com", grouped[0].Subscriptions[0].Email)
+
+	// Midnight in Tokyo (UTC+9) is 15:00 UTC, with the window starting the day before.
+	tokyoMidnight := time.Date(2025, 6, 26, 15, 0, 0, 0, time.UTC)
+	grouped, err = repo.FindDueSubscriptions(ctx, tokyoMidnight.Add(-time.Minute), tokyoMidnight)
+	suite.Require().NoError(err)
+	suite.Require().Len(grouped, 1)
+	suite.Equal("Tokyo", grouped[0].City)
+
+	grouped, err = repo.FindDueSubscriptions(ctx, kyivMorning, kyivMorning.Add(time.Minute))
+	suite.Require().NoError(err)
+	suite.Empty(grouped)
+}
+
+func (suite *SubscriptionControllerTestSuite) TestFindScheduledSubscriptions() {
+	now := time.Date(2025, 6, 26, 5, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	subscriptions := []*domain.Subscription{
+		{Email: "new@example.com"},
+		{Email: "due@example.com", NextRunAt: &now},
+		{Email: "later@example.com", NextRunAt: &later},
+	}
+	for i, sub := range subscriptions {
+		sub.City = "Kyiv"
+		sub.Frequency = domain.FrequencyCustom
+		sub.Schedule = "weekdays at 08:00"
+		sub.DeliveryTime = domain.DefaultDeliveryTime
+		sub.Timezone = "Europe/Kyiv"
+		sub.Token = fmt.Sprintf("scheduled-token-%d", i)
+		sub.Confirmed = true
+		suite.insertTestData(sub)
+	}
+	repo := postgresconnector.NewSubscriptionRepository(suite.DB)
+	ctx := context.Background()
+
+	grouped, err := repo.FindScheduledSubscriptions(ctx, now)
+	suite.Require().NoError(err)
+	suite.Require().Len(grouped, 1)
+	suite.Require().Len(grouped[0].Subscriptions, 2)
+
+	tomorrow := now.Add(24 * time.Hour)
+	err = repo.SetNextRuns(ctx, map[uint]time.Time{
+		subscriptions[0].ID: tomorrow,
+		subscriptions[1].ID: tomorrow,
+	})
+	suite.Require().NoError(err)
+
+	grouped, err = repo.FindScheduledSubscriptions(ctx, later)
+	suite.Require().NoError(err)
+	suite.Require().Len(grouped, 1)
+	suite.Require().Len(grouped[0].Subscriptions, 1)
+	suite.Equal("later@example.com", grouped[0].Subscriptions[0].Email)
+}
+
+func (suite *SubscriptionControllerTestSuite) TestFindByEmail() {
+	subscriptions := []*domain.Subscription{
+		{Email: "user@example.com", City: "Kyiv", Frequency: domain.FrequencyDaily},
+		{Email: "User@Example.com", City: "Lviv", Frequency: domain.FrequencyHourly},
+		{Email: "other@example.com", City: "Kyiv", Frequency: domain.FrequencyDaily},
+	}
+	for i, sub := range subscriptions {
+		sub.Token = fmt.Sprintf("email-token-%d", i)
+		sub.DeliveryTime = domain.DefaultDeliveryTime
+		sub.Timezone = domain.DefaultTimezone
+		suite.insertTestData(sub)
+	}
+	repo := postgresconnector.NewSubscriptionRepository(suite.DB)
+	ctx := context.Background()
+
+	found, err := repo.FindByEmail(ctx, "user@example.com")
+	suite.Require().NoError(err)
+	suite.Require().Len(found, 2)
+	suite.Equal("Kyiv", found[0].City)
+	suite.Equal("Lviv", found[1].City)
+
+	owned, err := repo.FindByIDAndEmail(ctx, subscriptions[1].ID, "user@example.com")
+	suite.Require().NoError(err)
+	suite.Require().NotNil(owned)
+	suite.Equal("Lviv", owned.City)
+
+	foreign, err := repo.FindByIDAndEmail(ctx, subscriptions[2].ID, "user@example.com")
+	suite.Require().NoError(err)
+	suite.Nil(foreign)
+}
+
+func (suite *SubscriptionControllerTestSuite) TestExistByLookup_IgnoresEmailCase() {
+	suite.insertTestData(&domain.Subscription{
+		Email: "user@example.com", City: "Kyiv", Frequency: domain.FrequencyDaily,
+		Token: "lookup-token", DeliveryTime: domain.DefaultDeliveryTime,
+		Timezone: domain.DefaultTimezone,
+	})
+	repo := postgresconnector.NewSubscriptionRepository(suite.DB)
+
+	exists, err := repo.ExistByLookup(context.Background(), &domain.SubscriptionLookup{
+		Email: "User@Example.com", City: "Kyiv", Frequency: domain.FrequencyDaily,
+	})
+
+	suite.Require().NoError(err)
+	suite.True(exists)
+}
+
 func TestSubscriptionControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(SubscriptionControllerTestSuite))
 }
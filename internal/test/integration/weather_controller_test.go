@@ -16,6 +16,7 @@ import (
 	"weather-api/internal/infrastructure/db/redis/weather/ttl"
 
 	"weather-api/internal/application/services/weather"
+	"weather-api/internal/infrastructure/db/cache"
 	cacheClient "weather-api/internal/infrastructure/db/redis/weather"
 	"weather-api/internal/infrastructure/prometheus"
 	"weather-api/internal/interface/rest"
@@ -50,8 +51,11 @@ func (suite *WeatherControllerTestSuite) SetupSuite() {
 
 	cachedWeatherApiClient := cacheClient.NewProxyClient(
 		weatherRepo,
-		redisContainer.Client,
+		cache.NewRedisCache(redisContainer.Client, nil, nil),
+		cache.JSONCodec{},
+		cache.NewCoalescer(nil, 0),
 		ttl.NewTTLProvider(15*time.Minute, infrastructure.SystemClock{}),
+		infrastructure.SystemClock{},
 		"weather-api",
 		weatherMetrics,
 	)
@@ -110,7 +114,8 @@ func (suite *WeatherControllerTestSuite) TestGetWeatherInvalidQueryParam() {
 
 func (suite *WeatherControllerTestSuite) TestCacheHitAndMiss() {
 	city := "London"
-	cacheKey := fmt.Sprintf("weather-api:%s:%s", "current", strings.ToLower(city))
+	cacheKey := fmt.Sprintf("weather-api:v%d:%s:%s",
+		cacheClient.SchemaVersion, "current", strings.ToLower(city))
 
 	req1, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/weather?city=%s", city), nil)
 	resp1 := httptest.NewRecorder()
@@ -24,3 +24,8 @@ func (m *MockEmailSender) WeatherHourlyEmail(mail *email.WeatherHourlyEmail) err
 	args := m.Called(mail)
 	return args.Error(0)
 }
+
+func (m *MockEmailSender) ManageLinkEmail(mail *email.ManageLinkEmail) error {
+	args := m.Called(mail)
+	return args.Error(0)
+}
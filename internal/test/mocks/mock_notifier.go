@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"weather-api/internal/domain"
@@ -18,9 +20,9 @@ func (m *MockNotifier) NotifyDailyWeather(
 }
 
 func (m *MockNotifier) NotifyHourlyWeather(
-	subscription *domain.Subscription, weatherHourly *domain.WeatherHourly,
+	subscription *domain.Subscription, timeline *domain.WeatherTimeline,
 ) error {
-	args := m.Called(subscription, weatherHourly)
+	args := m.Called(subscription, timeline)
 	return args.Error(0)
 }
 
@@ -30,3 +32,8 @@ func (m *MockNotifier) NotifyConfirmation(
 	args := m.Called(subscription)
 	return args.Error(0)
 }
+
+func (m *MockNotifier) NotifyManageLink(email, session string, expiresAt time.Time) error {
+	args := m.Called(email, session, expiresAt)
+	return args.Error(0)
+}
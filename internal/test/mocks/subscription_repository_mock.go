@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"weather-api/internal/domain"
 
@@ -39,6 +40,26 @@ func (m *MockSubscriptionRepository) FindByToken(ctx context.Context,
 	return args.Get(0).(*domain.Subscription), args.Error(1)
 }
 
+func (m *MockSubscriptionRepository) FindByEmail(ctx context.Context,
+	email string,
+) ([]*domain.Subscription, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) FindByIDAndEmail(ctx context.Context,
+	id uint, email string,
+) (*domain.Subscription, error) {
+	args := m.Called(ctx, id, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Subscription), args.Error(1)
+}
+
 func (m *MockSubscriptionRepository) Update(ctx context.Context,
 	subscription *domain.Subscription,
 ) (*domain.Subscription, error) {
@@ -63,3 +84,30 @@ func (m *MockSubscriptionRepository) FindGroupedSubscriptions(ctx context.Contex
 	}
 	return args.Get(0).([]*domain.GroupedSubscription), args.Error(1)
 }
+
+func (m *MockSubscriptionRepository) FindScheduledSubscriptions(ctx context.Context,
+	until time.Time,
+) ([]*domain.GroupedSubscription, error) {
+	args := m.Called(ctx, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GroupedSubscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) SetNextRuns(ctx context.Context,
+	runs map[uint]time.Time,
+) error {
+	args := m.Called(ctx, runs)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) FindDueSubscriptions(ctx context.Context,
+	from, to time.Time,
+) ([]*domain.GroupedSubscription, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GroupedSubscription), args.Error(1)
+}
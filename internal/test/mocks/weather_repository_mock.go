@@ -44,3 +44,15 @@ func (m *MockWeatherRepository) GetHourlyForecast(
 	}
 	return args.Get(0).(*domain.WeatherHourly), args.Error(1)
 }
+
+func (m *MockWeatherRepository) GetHourlyTimeline(
+	ctx context.Context,
+	city string,
+	hours int,
+) (*domain.WeatherTimeline, error) {
+	args := m.Called(ctx, city, hours)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WeatherTimeline), args.Error(1)
+}
@@ -6,6 +6,7 @@ type SenderStub struct {
 	ConfirmationEmailFn  func(email *email.ConfirmationEmail) error
 	WeatherDailyEmailFn  func(email *email.WeatherDailyEmail) error
 	WeatherHourlyEmailFn func(email *email.WeatherHourlyEmail) error
+	ManageLinkEmailFn    func(email *email.ManageLinkEmail) error
 }
 
 func NewSenderStub() *SenderStub {
@@ -13,6 +14,7 @@ func NewSenderStub() *SenderStub {
 		ConfirmationEmailFn:  nil,
 		WeatherDailyEmailFn:  nil,
 		WeatherHourlyEmailFn: nil,
+		ManageLinkEmailFn:    nil,
 	}
 }
 
@@ -36,3 +38,10 @@ func (s *SenderStub) WeatherHourlyEmail(email *email.WeatherHourlyEmail) error {
 	}
 	return nil
 }
+
+func (s *SenderStub) ManageLinkEmail(email *email.ManageLinkEmail) error {
+	if s.ManageLinkEmailFn != nil {
+		return s.ManageLinkEmailFn(email)
+	}
+	return nil
+}
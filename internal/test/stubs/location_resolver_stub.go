@@ -0,0 +1,24 @@
+package stubs
+
+import (
+	"context"
+
+	"weather-api/internal/domain"
+)
+
+type LocationResolverStub struct {
+	ResolveFn func(city string) (*domain.Location, error)
+}
+
+func NewLocationResolverStub() *LocationResolverStub {
+	return &LocationResolverStub{
+		ResolveFn: nil,
+	}
+}
+
+func (s *LocationResolverStub) Resolve(ctx context.Context, city string) (*domain.Location, error) {
+	if s.ResolveFn != nil {
+		return s.ResolveFn(city)
+	}
+	return &domain.Location{Name: city, Timezone: "Europe/Kyiv"}, nil
+}
@@ -2,6 +2,7 @@ package stubs
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"weather-api/internal/domain"
@@ -15,6 +16,7 @@ type WeatherRepositoryStub struct {
 	GetWeatherFn        func(city string) (*domain.Weather, error)
 	GetDailyForecastFn  func(city string) (*domain.WeatherDaily, error)
 	GetHourlyForecastFn func(city string) (*domain.WeatherHourly, error)
+	GetHourlyTimelineFn func(city string, hours int) (*domain.WeatherTimeline, error)
 }
 
 func NewWeatherRepositoryStub() *WeatherRepositoryStub {
@@ -22,6 +24,7 @@ func NewWeatherRepositoryStub() *WeatherRepositoryStub {
 		GetWeatherFn:        nil,
 		GetDailyForecastFn:  nil,
 		GetHourlyForecastFn: nil,
+		GetHourlyTimelineFn: nil,
 		callCount:           make(map[string]int),
 	}
 }
@@ -88,6 +91,28 @@ func (s *WeatherRepositoryStub) GetHourlyForecast(
 	}, nil
 }
 
+func (s *WeatherRepositoryStub) GetHourlyTimeline(
+	ctx context.Context,
+	city string,
+	hours int,
+) (*domain.WeatherTimeline, error) {
+	if s.GetHourlyTimelineFn != nil {
+		return s.GetHourlyTimelineFn(city, hours)
+	}
+	timeline := &domain.WeatherTimeline{Location: city}
+	for hour := range hours {
+		timeline.Hours = append(timeline.Hours, domain.WeatherHourly{
+			Location:   city,
+			Time:       fmt.Sprintf("%02d:00", (12+hour)%24),
+			TempC:      20.0,
+			ChanceRain: 5,
+			Condition:  "Partly cloudy",
+			Icon:       "cloudy.png",
+		})
+	}
+	return timeline, nil
+}
+
 func (s *WeatherRepositoryStub) GetCallCount(city string) int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id SERIAL PRIMARY KEY,
+    actor VARCHAR(100) NOT NULL,
+    remote_addr VARCHAR(64) NOT NULL DEFAULT '',
+    action VARCHAR(50) NOT NULL,
+    target VARCHAR(255) NOT NULL DEFAULT '',
+    outcome TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at);
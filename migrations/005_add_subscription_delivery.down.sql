@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS delivery_time;
@@ -0,0 +1,10 @@
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS delivery_time VARCHAR(5) NOT NULL DEFAULT '08:00',
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+UPDATE subscriptions AS s
+SET timezone = l.timezone
+FROM locations AS l
+WHERE l.query = LOWER(TRIM(s.city))
+  AND l.timezone <> ''
+  AND EXISTS (SELECT 1 FROM pg_timezone_names WHERE name = l.timezone);
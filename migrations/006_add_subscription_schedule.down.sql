@@ -0,0 +1,9 @@
+DELETE FROM subscriptions WHERE frequency = 'custom';
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS schedule;
+
+ALTER TYPE frequency_enum RENAME TO frequency_enum_old;
+CREATE TYPE frequency_enum AS ENUM ('daily', 'hourly');
+ALTER TABLE subscriptions
+    ALTER COLUMN frequency TYPE frequency_enum USING frequency::text::frequency_enum;
+DROP TYPE frequency_enum_old;
@@ -0,0 +1,6 @@
+-- The new value is not used in this migration, so it may be added inside the
+-- migration's transaction.
+ALTER TYPE frequency_enum ADD VALUE IF NOT EXISTS 'custom';
+
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS schedule VARCHAR(100) NOT NULL DEFAULT '';
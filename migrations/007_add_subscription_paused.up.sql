@@ -0,0 +1,4 @@
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS paused BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_email_lower ON subscriptions(LOWER(email));
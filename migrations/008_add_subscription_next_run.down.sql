@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_subscriptions_next_run_at;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS next_run_at;
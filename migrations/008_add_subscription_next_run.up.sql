@@ -0,0 +1,5 @@
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS next_run_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_next_run_at
+    ON subscriptions(next_run_at) WHERE frequency = 'custom';
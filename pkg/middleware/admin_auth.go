@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	internalErrors "weather-api/internal/errors"
+	pkgErrors "weather-api/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AdminActorKey = "adminActor"
+	bearerPrefix  = "Bearer "
+)
+
+// AdminAuth admits requests carrying one of the admin tokens as a bearer token
+// and records the actor that token belongs to, so callers cannot name
+// themselves in the audit log. Without tokens every request is rejected, so
+// the admin API stays closed until it is configured.
+func AdminAuth(credentials map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided, found := strings.CutPrefix(c.GetHeader("Authorization"), bearerPrefix)
+		actor, ok := adminActor(credentials, provided)
+		if !found || !ok {
+			c.Error(pkgErrors.New(internalErrors.ErrUnauthorized, "invalid admin token")) //nolint:errcheck
+			c.Abort()
+			return
+		}
+
+		c.Set(AdminActorKey, actor)
+		c.Next()
+	}
+}
+
+// adminActor compares the provided token against every credential in
+// constant time.
+func adminActor(credentials map[string]string, provided string) (string, bool) {
+	var actor string
+	for token, name := range credentials {
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1 {
+			actor = name
+		}
+	}
+	return actor, actor != ""
+}
@@ -64,8 +64,13 @@ func ToHTTPStatus(err error) (int, bool) {
 		return http.StatusConflict, true
 	case errors.Is(err, internalErrors.ErrInvalidInput):
 		return http.StatusBadRequest, true
-	case errors.Is(err, internalErrors.ErrServiceUnavailable):
+	case errors.Is(err, internalErrors.ErrUnauthorized):
+		return http.StatusUnauthorized, true
+	case errors.Is(err, internalErrors.ErrServiceUnavailable),
+		errors.Is(err, internalErrors.ErrQuotaExceeded):
 		return http.StatusServiceUnavailable, true
+	case errors.Is(err, internalErrors.ErrInvalidData):
+		return http.StatusBadGateway, true
 	case errors.Is(err, internalErrors.ErrInternal):
 		return http.StatusInternalServerError, true
 	default: